@@ -0,0 +1,60 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+// ErrorAccumulator records errors instead of escaping on the first one, so
+// that several independent steps can run and report all of their failures at
+// once. It is the accumulating counterpart of calling Eh() on every Result.
+// The zero value is ready to use. An ErrorAccumulator is not safe for
+// concurrent use.
+//
+// Example:
+//
+//	func example(form Form) (res eh.Result[User]) {
+//		defer eh.EscapeHatch(&res)
+//		var acc eh.ErrorAccumulator
+//		name := eh.Accumulate(&acc, parseName(form.Name))
+//		age := eh.Accumulate(&acc, parseAge(form.Age))
+//		// escape with all the errors joined together
+//		eh.FromFailable(acc.Err()).Eh()
+//		return eh.Result[User]{Ok: User{name, age}}
+//	}
+type ErrorAccumulator struct {
+	errs []error
+}
+
+// Add records err if it is not nil.
+func (a *ErrorAccumulator) Add(err error) {
+	if err != nil {
+		a.errs = append(a.errs, err)
+	}
+}
+
+// Err returns the recorded errors as a *MultiError in the order they were
+// added, or nil if no errors were recorded.
+func (a *ErrorAccumulator) Err() error {
+	return joinErrors(a.errs...)
+}
+
+// Accumulate records the error of r in a, if there is one, and returns the
+// Ok value of r. The zero value of T is returned when r is errored.
+func Accumulate[T any](a *ErrorAccumulator, r Result[T]) T {
+	if r.Err != nil {
+		a.Add(r.Err)
+		var zero T
+		return zero
+	}
+	return r.Ok
+}
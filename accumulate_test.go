@@ -0,0 +1,60 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrorAccumulator(t *testing.T) {
+	var acc ErrorAccumulator
+	if acc.Err() != nil {
+		t.Fatal("empty accumulator should not have an error")
+	}
+	firstErr := errors.New("first")
+	secondErr := errors.New("second")
+	if val := Accumulate(&acc, Result[int]{Ok: 1}); val != 1 {
+		t.Fatalf("Ok value should be returned, got %d", val)
+	}
+	if val := Accumulate(&acc, Result[int]{Ok: 1, Err: firstErr}); val != 0 {
+		t.Fatalf("zero value should be returned, got %d", val)
+	}
+	acc.Add(nil)
+	acc.Add(secondErr)
+	err := acc.Err()
+	if err.Error() != "first\nsecond" || !errors.Is(err, firstErr) || !errors.Is(err, secondErr) {
+		t.Fatalf("errors should be joined in order, got %v", err)
+	}
+}
+
+func TestErrorAccumulatorEscape(t *testing.T) {
+	divideAll := func(x int, ys ...int) (res Result[[]int]) {
+		defer EscapeHatch(&res)
+		var acc ErrorAccumulator
+		var vals []int
+		for _, y := range ys {
+			vals = append(vals, Accumulate(&acc, NewResult(divide(x, y))))
+		}
+		FromFailable(acc.Err()).Eh()
+		return Result[[]int]{Ok: vals}
+	}
+	if res := divideAll(4, 0, 2, 0); res.Err.Error() != "divide by zero\ndivide by zero" {
+		t.Fatalf("all errors should escape together %+v", res)
+	}
+	if res := divideAll(4, 2, 1); res.IsErr() || len(res.Ok) != 2 {
+		t.Fatalf("result should be Ok %+v", res)
+	}
+}
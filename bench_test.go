@@ -0,0 +1,77 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+var errBench = errors.New("bench error")
+
+// failEvery returns an error for pct percent of the values of i.
+func failEvery(i int, pct int) (int, error) {
+	if i%100 < pct {
+		return 0, errBench
+	}
+	return i, nil
+}
+
+func benchEh(i int, pct int) (res Result[int]) {
+	defer EscapeHatch(&res)
+	val := NewResult(failEvery(i, pct)).Eh()
+	return Result[int]{Ok: val + NewResult(failEvery(i+1, 0)).Eh()}
+}
+
+func benchOr(i int, pct int) (val int, err error) {
+	first := NewResult(failEvery(i, pct)).Or(&err)
+	second := NewResult(failEvery(i+1, 0)).Or(&err)
+	return first + second, err
+}
+
+func benchDirect(i int, pct int) (int, error) {
+	first, err := failEvery(i, pct)
+	if err != nil {
+		return 0, err
+	}
+	second, err := failEvery(i+1, 0)
+	if err != nil {
+		return 0, err
+	}
+	return first + second, nil
+}
+
+// BenchmarkErrorHandling compares the panic based Eh and EscapeHatch flow
+// with the panic free Or flow and plain error returns at different error rates.
+func BenchmarkErrorHandling(b *testing.B) {
+	for _, pct := range []int{0, 1, 50} {
+		b.Run(fmt.Sprintf("Eh/%d%%", pct), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = benchEh(i, pct)
+			}
+		})
+		b.Run(fmt.Sprintf("Or/%d%%", pct), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = benchOr(i, pct)
+			}
+		})
+		b.Run(fmt.Sprintf("Direct/%d%%", pct), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = benchDirect(i, pct)
+			}
+		})
+	}
+}
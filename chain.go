@@ -0,0 +1,60 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+// ResultChain wraps a Result so that steps that keep the type of the Ok
+// value can be chained with methods. It is created with Chain and the final
+// Result is returned by its Result method.
+type ResultChain[T any] struct {
+	r Result[T]
+}
+
+// Chain starts a chain of steps on r. Since methods cannot have their own
+// type parameters every step has to keep the type T. Steps that change the
+// type are written as a function with an EscapeHatch, or with Match.
+//
+// Example:
+//
+//	res := eh.Chain(parseAge(s)).
+//		Filter(func(a int) bool { return a >= 0 }, ErrNegativeAge).
+//		Inspect(logAge).
+//		Result()
+func Chain[T any](r Result[T]) ResultChain[T] {
+	return ResultChain[T]{r}
+}
+
+// AndThen calls f with the Ok value and continues the chain with the Result
+// returned by f. If there is an error f is not called.
+func (c ResultChain[T]) AndThen(f func(T) Result[T]) ResultChain[T] {
+	if c.r.IsErr() {
+		return c
+	}
+	return ResultChain[T]{f(c.r.Ok)}
+}
+
+// Filter is the same as the Filter function.
+func (c ResultChain[T]) Filter(pred func(T) bool, err error) ResultChain[T] {
+	return ResultChain[T]{Filter(c.r, pred, err)}
+}
+
+// Inspect is the same as the Inspect function.
+func (c ResultChain[T]) Inspect(f func(T)) ResultChain[T] {
+	return ResultChain[T]{Inspect(c.r, f)}
+}
+
+// Result returns the Result at the end of the chain.
+func (c ResultChain[T]) Result() Result[T] {
+	return c.r
+}
@@ -0,0 +1,46 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestChain(t *testing.T) {
+	errOdd := errors.New("odd")
+	even := func(v int) bool { return v%2 == 0 }
+	half := func(v int) Result[int] { return doDivide(v, 2) }
+	var seen []int
+	res := Chain(doDivide(8, 1)).
+		Filter(even, errOdd).
+		AndThen(half).
+		Inspect(func(v int) { seen = append(seen, v) }).
+		Result()
+	if res.IsErr() || res.Ok != 4 || len(seen) != 1 || seen[0] != 4 {
+		t.Fatalf("all steps should run %+v %v", res, seen)
+	}
+	res = Chain(doDivide(3, 1)).
+		Filter(even, errOdd).
+		AndThen(func(int) Result[int] {
+			t.Fatal("AndThen should not be called on an errored result")
+			return Result[int]{}
+		}).
+		Inspect(func(int) { t.Fatal("Inspect should not be called on an errored result") }).
+		Result()
+	if res.Err != errOdd {
+		t.Fatalf("the first error should be kept %+v", res)
+	}
+}
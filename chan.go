@@ -0,0 +1,50 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"sync"
+)
+
+// ToChan returns a closed channel that holds the result as its only value.
+// This makes it possible to select over Results that are already known
+// together with Results that are still being computed.
+func (r Result[T]) ToChan() <-chan Result[T] {
+	ch := make(chan Result[T], 1)
+	ch <- r
+	close(ch)
+	return ch
+}
+
+// FanIn merges the Results from all the channels into a single channel. The
+// returned channel is closed once all the input channels are closed.
+func FanIn[T any](chans ...<-chan Result[T]) <-chan Result[T] {
+	out := make(chan Result[T])
+	wg := sync.WaitGroup{}
+	wg.Add(len(chans))
+	for _, ch := range chans {
+		go func(ch <-chan Result[T]) {
+			defer wg.Done()
+			for res := range ch {
+				out <- res
+			}
+		}(ch)
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
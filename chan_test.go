@@ -0,0 +1,59 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"testing"
+)
+
+func TestToChan(t *testing.T) {
+	var results []Result[int]
+	for res := range doDivide(4, 2).ToChan() {
+		results = append(results, res)
+	}
+	if len(results) != 1 || results[0].Ok != 2 {
+		t.Fatalf("channel should contain exactly the result %+v", results)
+	}
+}
+
+func TestFanIn(t *testing.T) {
+	produce := func(ys ...int) <-chan Result[int] {
+		ch := make(chan Result[int])
+		go func() {
+			defer close(ch)
+			for _, y := range ys {
+				ch <- doDivide(12, y)
+			}
+		}()
+		return ch
+	}
+	sum, errs := 0, 0
+	for res := range FanIn(produce(1, 2), produce(0, 3), doDivide(12, 4).ToChan()) {
+		if res.IsErr() {
+			errs++
+			continue
+		}
+		sum += res.Ok
+	}
+	if sum != 25 || errs != 1 {
+		t.Fatalf("all results should be merged, got sum %d and %d errors", sum, errs)
+	}
+}
+
+func TestFanInEmpty(t *testing.T) {
+	for res := range FanIn[int]() {
+		t.Fatalf("channel should be empty %+v", res)
+	}
+}
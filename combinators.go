@@ -0,0 +1,216 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+// MapErr rewrites the error of an errored Result with f while leaving the Ok
+// value untouched. If the result is Ok it is returned unchanged and f is not called.
+//
+// Example:
+//
+//	func example(aFile string) (res eh.Result[[]byte]) {
+//		defer eh.EscapeHatch(&res)
+//		file := eh.MapErr(eh.NewResult(os.Open(aFile)), func(err error) error {
+//			return fmt.Errorf("reading config: %w", err)
+//		}).Eh()
+//		...
+//	}
+func MapErr[T any](r Result[T], f func(error) error) Result[T] {
+	if r.IsOk() {
+		return r
+	}
+	return Result[T]{Ok: r.Ok, Err: f(r.Err)}
+}
+
+// OrElse recovers from an errored Result by calling f with the error and
+// returning whatever Result it produces, including a new error. If the result
+// is Ok it is returned unchanged and f is not called.
+//
+// Example:
+//
+//	res := eh.OrElse(fromCache(key), func(_ error) eh.Result[string] {
+//		return fromDB(key)
+//	})
+func OrElse[T any](r Result[T], f func(error) Result[T]) Result[T] {
+	if r.IsOk() {
+		return r
+	}
+	return f(r.Err)
+}
+
+// FlatMapErr is the same as OrElse. It is the error counterpart of a flat
+// map, the Result returned by f replaces an errored result and f is not
+// called if the result is Ok.
+func FlatMapErr[T any](r Result[T], f func(error) Result[T]) Result[T] {
+	return OrElse(r, f)
+}
+
+// Match handles both outcomes of a Result in a single call. It returns the
+// output of onOk when the result is Ok and the output of onErr otherwise.
+// Only one of the callbacks is ever invoked.
+//
+// Example:
+//
+//	status := eh.Match(res,
+//		func(_ User) int { return http.StatusOK },
+//		func(_ error) int { return http.StatusInternalServerError })
+func Match[T, U any](r Result[T], onOk func(T) U, onErr func(error) U) U {
+	if r.IsOk() {
+		return onOk(r.Ok)
+	}
+	return onErr(r.Err)
+}
+
+// Filter turns an Ok result whose value does not satisfy pred into a Result
+// that contains err. Results that are already errored are returned unchanged
+// and pred is not called for them.
+//
+// Example:
+//
+//	age := eh.Filter(parseAge(s), func(a int) bool { return a >= 0 }, ErrNegativeAge).Eh()
+func Filter[T any](r Result[T], pred func(T) bool, err error) Result[T] {
+	if r.IsErr() || pred(r.Ok) {
+		return r
+	}
+	return Result[T]{Err: err}
+}
+
+// Validate runs all the checks on the Ok value of r and returns r unchanged
+// if none of them fails. Otherwise the errors of all the failed checks are
+// joined in a *MultiError, in the order of the checks, which becomes the
+// error of the returned Result. Results that are already errored are
+// returned unchanged and the checks are not run for them.
+//
+// Example:
+//
+//	user := eh.Validate(decodeUser(body), checkName, checkEmail, checkAge).Eh()
+func Validate[T any](r Result[T], checks ...func(T) error) Result[T] {
+	if r.IsErr() {
+		return r
+	}
+	errs := make([]error, len(checks))
+	for i, check := range checks {
+		errs[i] = check(r.Ok)
+	}
+	if err := joinErrors(errs...); err != nil {
+		return Result[T]{Err: err}
+	}
+	return r
+}
+
+// Inspect calls f with the Ok value if there is no error and returns the
+// result unchanged. It is useful for logging or collecting metrics.
+func Inspect[T any](r Result[T], f func(T)) Result[T] {
+	if r.IsOk() {
+		f(r.Ok)
+	}
+	return r
+}
+
+// InspectErr calls f with the error if there is one and returns the result
+// unchanged. It is useful for logging or collecting metrics.
+func InspectErr[T any](r Result[T], f func(error)) Result[T] {
+	if r.IsErr() {
+		f(r.Err)
+	}
+	return r
+}
+
+// Flatten removes one level of nesting from a Result of a Result. The error
+// of the outer result takes precedence over the error of the inner one.
+func Flatten[T any](r Result[Result[T]]) Result[T] {
+	if r.IsErr() {
+		return Result[T]{Err: r.Err}
+	}
+	return r.Ok
+}
+
+// Pair holds two values of possibly different types.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip combines two Results into a Result of a Pair holding both Ok values.
+// If either of the Results is errored its error is returned, with the error
+// of a taking precedence when both are errored.
+func Zip[A, B any](a Result[A], b Result[B]) Result[Pair[A, B]] {
+	if a.IsErr() {
+		return Result[Pair[A, B]]{Err: a.Err}
+	}
+	if b.IsErr() {
+		return Result[Pair[A, B]]{Err: b.Err}
+	}
+	return Result[Pair[A, B]]{Ok: Pair[A, B]{a.Ok, b.Ok}}
+}
+
+// ZipAll is like Zip but when both Results are errored the returned error
+// joins both errors in a *MultiError, so errors.Is matches either of them.
+func ZipAll[A, B any](a Result[A], b Result[B]) Result[Pair[A, B]] {
+	if a.IsErr() && b.IsErr() {
+		return Result[Pair[A, B]]{Err: joinErrors(a.Err, b.Err)}
+	}
+	return Zip(a, b)
+}
+
+// Apply calls the fallible consumer f with the Ok value. If f returns an
+// error it is returned in a new Result, otherwise the result is returned
+// unchanged. Results that are already errored are returned unchanged and f
+// is not called for them.
+//
+// Example:
+//
+//	res := eh.Apply(render(page), func(html []byte) error {
+//		return os.WriteFile("index.html", html, 0o644)
+//	})
+func Apply[T any](r Result[T], f func(T) error) Result[T] {
+	if r.IsErr() {
+		return r
+	}
+	if err := f(r.Ok); err != nil {
+		return Result[T]{Err: err}
+	}
+	return r
+}
+
+// MapErrInto is like MapErr but conv converts the error into a specific error
+// type E, which is useful when adapting the errors of one library to the
+// error types of another. The converted error can be retrieved with
+// errors.As. The function conv is only called if there is an error and it
+// must not return a nil E, since that would still be a non-nil error.
+//
+// Example:
+//
+//	res := eh.MapErrInto(fetch(url), func(err error) *APIError {
+//		return &APIError{Status: http.StatusBadGateway, Err: err}
+//	})
+func MapErrInto[T any, E error](r Result[T], conv func(error) E) Result[T] {
+	if r.IsOk() {
+		return r
+	}
+	return Result[T]{Ok: r.Ok, Err: conv(r.Err)}
+}
+
+// Deref dereferences the Ok pointer of a Result. If the pointer is nil the
+// returned Result contains ErrNilPointer instead, and errored Results are
+// propagated unchanged.
+func Deref[T any](r Result[*T]) Result[T] {
+	if r.IsErr() {
+		return Result[T]{Err: r.Err}
+	}
+	if r.Ok == nil {
+		return Result[T]{Err: ErrNilPointer}
+	}
+	return Result[T]{Ok: *r.Ok}
+}
@@ -0,0 +1,281 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestMapErr(t *testing.T) {
+	aErr := errors.New("error")
+	res := MapErr(Result[int]{Err: aErr}, func(err error) error {
+		return fmt.Errorf("wrapped: %w", err)
+	})
+	if res.Err.Error() != "wrapped: error" || !errors.Is(res.Err, aErr) {
+		t.Fatalf("error was not mapped %+v", res)
+	}
+}
+
+func TestMapErrOk(t *testing.T) {
+	res := MapErr(Result[int]{Ok: 1}, func(err error) error {
+		t.Fatal("f should not be called on an Ok result")
+		return err
+	})
+	if res.Ok != 1 || res.IsErr() {
+		t.Fatalf("Ok result should be unchanged %+v", res)
+	}
+}
+
+func TestOrElse(t *testing.T) {
+	res := OrElse(doDivide(1, 0), func(_ error) Result[int] {
+		return doDivide(4, 2)
+	})
+	if res.IsErr() || res.Ok != 2 {
+		t.Fatalf("result should have been recovered %+v", res)
+	}
+}
+
+func TestOrElseNewError(t *testing.T) {
+	aErr := errors.New("error")
+	res := OrElse(doDivide(1, 0), func(_ error) Result[int] {
+		return Result[int]{Err: aErr}
+	})
+	if res.Err != aErr {
+		t.Fatalf("error from f should be returned %+v", res)
+	}
+}
+
+func TestOrElseOk(t *testing.T) {
+	res := OrElse(doDivide(4, 2), func(_ error) Result[int] {
+		t.Fatal("f should not be called on an Ok result")
+		return Result[int]{}
+	})
+	if res.Ok != 2 {
+		t.Fatalf("Ok result should be unchanged %+v", res)
+	}
+}
+
+func TestFlatMapErr(t *testing.T) {
+	aErr := errors.New("error")
+	res := FlatMapErr(doDivide(1, 0), func(err error) Result[int] {
+		return Result[int]{Err: fmt.Errorf("%w: %w", aErr, err)}
+	})
+	if !errors.Is(res.Err, aErr) || res.Err.Error() != "error: divide by zero" {
+		t.Fatalf("error from f should be returned %+v", res)
+	}
+	res = FlatMapErr(doDivide(4, 2), func(_ error) Result[int] {
+		t.Fatal("f should not be called on an Ok result")
+		return Result[int]{}
+	})
+	if res.Ok != 2 {
+		t.Fatalf("Ok result should be unchanged %+v", res)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	onOk := func(v int) string { return fmt.Sprintf("ok %d", v) }
+	onErr := func(err error) string { return err.Error() }
+	if out := Match(doDivide(4, 2), onOk, onErr); out != "ok 2" {
+		t.Fatalf("onOk should have been called, got %q", out)
+	}
+	if out := Match(doDivide(1, 0), onOk, onErr); out != "divide by zero" {
+		t.Fatalf("onErr should have been called, got %q", out)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	errOdd := errors.New("odd")
+	even := func(v int) bool { return v%2 == 0 }
+	if res := Filter(doDivide(4, 2), even, errOdd); res.IsErr() || res.Ok != 2 {
+		t.Fatalf("value passing the predicate should be kept %+v", res)
+	}
+	if res := Filter(doDivide(9, 3), even, errOdd); res.Err != errOdd || res.Ok != 0 {
+		t.Fatalf("value failing the predicate should become an error %+v", res)
+	}
+	res := Filter(doDivide(1, 0), func(_ int) bool {
+		t.Fatal("pred should not be called on an errored result")
+		return true
+	}, errOdd)
+	if res.Err.Error() != "divide by zero" {
+		t.Fatalf("errored result should be unchanged %+v", res)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	errOdd := errors.New("odd")
+	errSmall := errors.New("small")
+	even := func(v int) error {
+		if v%2 != 0 {
+			return errOdd
+		}
+		return nil
+	}
+	large := func(v int) error {
+		if v < 10 {
+			return errSmall
+		}
+		return nil
+	}
+	if res := Validate(doDivide(40, 2), even, large); res.IsErr() || res.Ok != 20 {
+		t.Fatalf("valid value should be kept %+v", res)
+	}
+	res := Validate(doDivide(9, 3), even, large)
+	if !errors.Is(res.Err, errOdd) || !errors.Is(res.Err, errSmall) || res.Ok != 0 {
+		t.Fatalf("all failed checks should be joined %+v", res)
+	}
+	res = Validate(doDivide(1, 0), func(int) error {
+		t.Fatal("checks should not run on an errored result")
+		return nil
+	})
+	if res.Err.Error() != "divide by zero" {
+		t.Fatalf("errored result should be unchanged %+v", res)
+	}
+}
+
+func TestInspect(t *testing.T) {
+	var seen []int
+	record := func(v int) { seen = append(seen, v) }
+	Inspect(doDivide(4, 2), record)
+	res := Inspect(doDivide(1, 0), record)
+	if len(seen) != 1 || seen[0] != 2 {
+		t.Fatalf("f should only be called for Ok results %v", seen)
+	}
+	if res.Err.Error() != "divide by zero" {
+		t.Fatalf("result should be unchanged %+v", res)
+	}
+}
+
+func TestInspectErr(t *testing.T) {
+	var seen []error
+	record := func(err error) { seen = append(seen, err) }
+	InspectErr(doDivide(4, 2), record)
+	res := InspectErr(doDivide(1, 0), record)
+	if len(seen) != 1 || seen[0] != res.Err {
+		t.Fatalf("f should only be called for errored results %v", seen)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	outerErr := errors.New("outer")
+	if res := Flatten(Result[Result[int]]{Ok: doDivide(4, 2)}); res.IsErr() || res.Ok != 2 {
+		t.Fatalf("inner Ok result should be returned %+v", res)
+	}
+	if res := Flatten(Result[Result[int]]{Ok: doDivide(1, 0)}); res.Err.Error() != "divide by zero" {
+		t.Fatalf("inner error should be returned %+v", res)
+	}
+	if res := Flatten(Result[Result[int]]{Err: outerErr}); res.Err != outerErr {
+		t.Fatalf("outer error should be returned %+v", res)
+	}
+}
+
+func TestZip(t *testing.T) {
+	res := Zip(doDivide(4, 2), Result[string]{Ok: "two"})
+	if res.IsErr() || res.Ok.First != 2 || res.Ok.Second != "two" {
+		t.Fatalf("result should hold both values %+v", res)
+	}
+	aErr := errors.New("a")
+	bErr := errors.New("b")
+	if res := Zip(Result[int]{Ok: 1}, Result[string]{Err: bErr}); res.Err != bErr {
+		t.Fatalf("error of b should be returned %+v", res)
+	}
+	if res := Zip(Result[int]{Err: aErr}, Result[string]{Err: bErr}); res.Err != aErr {
+		t.Fatalf("error of a should be returned %+v", res)
+	}
+}
+
+func TestZipAll(t *testing.T) {
+	aErr := errors.New("a")
+	bErr := errors.New("b")
+	res := ZipAll(Result[int]{Err: aErr}, Result[string]{Err: bErr})
+	if !errors.Is(res.Err, aErr) || !errors.Is(res.Err, bErr) {
+		t.Fatalf("both errors should be joined %+v", res)
+	}
+	if res := ZipAll(Result[int]{Err: aErr}, Result[string]{Ok: "two"}); res.Err != aErr {
+		t.Fatalf("error of a should be returned %+v", res)
+	}
+	if res := ZipAll(Result[int]{Ok: 1}, Result[string]{Ok: "two"}); res.IsErr() || res.Ok.Second != "two" {
+		t.Fatalf("result should hold both values %+v", res)
+	}
+}
+
+func TestApply(t *testing.T) {
+	var written []int
+	write := func(v int) error {
+		if v > 2 {
+			return errors.New("too big")
+		}
+		written = append(written, v)
+		return nil
+	}
+	if res := Apply(doDivide(4, 2), write); res.IsErr() || res.Ok != 2 {
+		t.Fatalf("result should be unchanged %+v", res)
+	}
+	if res := Apply(doDivide(9, 3), write); res.IsOk() || res.Err.Error() != "too big" {
+		t.Fatalf("error of f should be returned %+v", res)
+	}
+	if res := Apply(doDivide(1, 0), write); res.Err.Error() != "divide by zero" {
+		t.Fatalf("errored result should be unchanged %+v", res)
+	}
+	if len(written) != 1 || written[0] != 2 {
+		t.Fatalf("f should only be called for Ok results %v", written)
+	}
+}
+
+type statusError struct {
+	status int
+	err    error
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("status %d: %v", e.status, e.err)
+}
+
+func (e *statusError) Unwrap() error {
+	return e.err
+}
+
+func TestMapErrInto(t *testing.T) {
+	toStatus := func(err error) *statusError {
+		return &statusError{status: 400, err: err}
+	}
+	res := MapErrInto(doDivide(1, 0), toStatus)
+	var se *statusError
+	if !errors.As(res.Err, &se) || se.status != 400 || se.err.Error() != "divide by zero" {
+		t.Fatalf("error should be converted %+v", res)
+	}
+	res = MapErrInto(doDivide(4, 2), func(err error) *statusError {
+		t.Fatal("conv should not be called on an Ok result")
+		return nil
+	})
+	if res.IsErr() || res.Ok != 2 {
+		t.Fatalf("Ok result should be unchanged %+v", res)
+	}
+}
+
+func TestDeref(t *testing.T) {
+	val := 2
+	if res := Deref(Result[*int]{Ok: &val}); res.IsErr() || res.Ok != 2 {
+		t.Fatalf("pointer should be dereferenced %+v", res)
+	}
+	if res := Deref(Result[*int]{}); !errors.Is(res.Err, ErrNilPointer) {
+		t.Fatalf("nil pointer should result in ErrNilPointer %+v", res)
+	}
+	aErr := errors.New("error")
+	if res := Deref(Result[*int]{Ok: &val, Err: aErr}); res.Err != aErr || res.Ok != 0 {
+		t.Fatalf("error should be propagated %+v", res)
+	}
+}
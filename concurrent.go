@@ -0,0 +1,159 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrTimeout is returned by WithTimeout when the function does not finish in time.
+var ErrTimeout = errors.New("eh: timed out")
+
+// ParallelMap calls f for each of the items in its own goroutine and returns
+// the Results in the same order as the items. A panic inside f, including an
+// error escaping through Eh(), is recovered and stored as the error of the
+// Result for that item.
+func ParallelMap[T, U any](items []T, f func(T) Result[U]) []Result[U] {
+	return ParallelMapN(items, len(items), f)
+}
+
+// ParallelMapN is like ParallelMap but at most n calls of f run at the same
+// time. If n is not positive there is no limit.
+func ParallelMapN[T, U any](items []T, n int, f func(T) Result[U]) []Result[U] {
+	results := make([]Result[U], len(items))
+	if n <= 0 || n > len(items) {
+		n = len(items)
+	}
+	indexes := make(chan int)
+	wg := sync.WaitGroup{}
+	wg.Add(n)
+	for w := 0; w < n; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = callRecovered(f, items[i])
+			}
+		}()
+	}
+	for i := range items {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+// AllSettled calls all the functions concurrently, waits for all of them to
+// finish and returns their Results in the same order as the functions. A
+// panic inside a function is recovered and stored as the error of its
+// Result, in the same way as in ParallelMap.
+//
+// Example:
+//
+//	results := eh.AllSettled(
+//		func() eh.Result[int] { return fetchCount(ctx, "users") },
+//		func() eh.Result[int] { return fetchCount(ctx, "orders") },
+//	)
+func AllSettled[T any](fs ...func() Result[T]) []Result[T] {
+	return ParallelMap(fs, func(f func() Result[T]) Result[T] { return f() })
+}
+
+// Race calls all the functions concurrently and returns the first Ok Result.
+// The context passed to the functions is canceled as soon as one of them
+// succeeds so that the others can stop early. If all the functions fail the
+// Result contains the errors of all of them, in the order of the functions,
+// joined in a *MultiError. If ctx is done before any function succeeds the
+// Result contains the context error. A panic inside a function is treated
+// like an error returned by it.
+func Race[T any](ctx context.Context, fs ...func(context.Context) Result[T]) Result[T] {
+	if len(fs) == 0 {
+		return Result[T]{Err: errors.New("eh: no functions to race")}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type indexed struct {
+		i   int
+		res Result[T]
+	}
+	done := make(chan indexed, len(fs))
+	for i, f := range fs {
+		go func(i int, f func(context.Context) Result[T]) {
+			done <- indexed{i, callRecovered(f, ctx)}
+		}(i, f)
+	}
+	errs := make([]error, len(fs))
+	for range fs {
+		select {
+		case <-ctx.Done():
+			return Result[T]{Err: ctx.Err()}
+		case d := <-done:
+			if d.res.IsOk() {
+				return d.res
+			}
+			errs[d.i] = d.res.Err
+		}
+	}
+	return Result[T]{Err: joinErrors(errs...)}
+}
+
+// WithTimeout calls f in a new goroutine and returns its Result, unless d
+// elapses first in which case the Result contains ErrTimeout. When that
+// happens f keeps running in the background until it returns, its Result is
+// then discarded. Use WithDeadline if f should be told to stop early. A panic
+// inside f is converted into an errored Result.
+func WithTimeout[T any](d time.Duration, f func() Result[T]) Result[T] {
+	done := make(chan Result[T], 1)
+	go func() {
+		done <- callRecovered(func(struct{}) Result[T] { return f() }, struct{}{})
+	}()
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case res := <-done:
+		return res
+	case <-timer.C:
+		return Result[T]{Err: ErrTimeout}
+	}
+}
+
+// WithDeadline calls f in a new goroutine and returns its Result, unless ctx
+// is done first in which case the Result contains the context error. The
+// context is passed to f so that it can stop early, f keeps running in the
+// background otherwise. A panic inside f is converted into an errored Result.
+func WithDeadline[T any](ctx context.Context, f func(context.Context) Result[T]) Result[T] {
+	done := make(chan Result[T], 1)
+	go func() {
+		done <- callRecovered(f, ctx)
+	}()
+	select {
+	case res := <-done:
+		return res
+	case <-ctx.Done():
+		return Result[T]{Err: ctx.Err()}
+	}
+}
+
+// callRecovered calls f with v and converts a panic into an errored Result.
+func callRecovered[T, U any](f func(T) Result[U], v T) (res Result[U]) {
+	defer func() {
+		if r := recover(); r != nil {
+			res = Result[U]{Err: panicError(r)}
+		}
+	}()
+	return f(v)
+}
@@ -0,0 +1,172 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParallelMap(t *testing.T) {
+	results := ParallelMap([]int{2, 0, 4}, func(y int) Result[int] {
+		return doDivide(8, y)
+	})
+	if len(results) != 3 || results[0].Ok != 4 || results[1].IsOk() || results[2].Ok != 2 {
+		t.Fatalf("results should be in the order of the items %+v", results)
+	}
+}
+
+func TestParallelMapPanic(t *testing.T) {
+	results := ParallelMap([]int{2, 0}, func(y int) Result[int] {
+		if y == 0 {
+			panic("boom")
+		}
+		return Result[int]{Ok: NewResult(divide(8, y)).Eh()}
+	})
+	if results[0].Ok != 4 || results[1].Err.Error() != "boom" {
+		t.Fatalf("panic should be converted into an error %+v", results)
+	}
+	results = ParallelMap([]int{0}, func(y int) Result[int] {
+		return Result[int]{Ok: NewResult(divide(8, y)).Eh()}
+	})
+	if results[0].Err.Error() != "divide by zero" {
+		t.Fatalf("escaped error should be recovered %+v", results)
+	}
+}
+
+func TestParallelMapN(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	items := make([]int, 10)
+	results := ParallelMapN(items, 3, func(v int) Result[int] {
+		cur := running.Add(1)
+		defer running.Add(-1)
+		for {
+			prev := maxRunning.Load()
+			if cur <= prev || maxRunning.CompareAndSwap(prev, cur) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		return Result[int]{Ok: v}
+	})
+	if len(results) != 10 {
+		t.Fatalf("there should be a result for every item %+v", results)
+	}
+	if maxRunning.Load() > 3 {
+		t.Fatalf("at most 3 calls should run at once, %d did", maxRunning.Load())
+	}
+}
+
+func TestAllSettled(t *testing.T) {
+	results := AllSettled(
+		func() Result[int] { time.Sleep(10 * time.Millisecond); return doDivide(4, 2) },
+		func() Result[int] { return doDivide(4, 0) },
+		func() Result[int] { panic("boom") },
+	)
+	if len(results) != 3 || results[0].Ok != 2 || results[1].IsOk() {
+		t.Fatalf("results should be in the order of the functions %+v", results)
+	}
+	if results[2].IsOk() || results[2].Err.Error() != "boom" {
+		t.Fatalf("panic should be recovered into an error %+v", results[2])
+	}
+	if results := AllSettled[int](); len(results) != 0 {
+		t.Fatalf("no functions should result in no results %+v", results)
+	}
+}
+
+func TestRace(t *testing.T) {
+	slow := func(ctx context.Context) Result[string] {
+		select {
+		case <-ctx.Done():
+			return Result[string]{Err: ctx.Err()}
+		case <-time.After(time.Second):
+			return Result[string]{Ok: "slow"}
+		}
+	}
+	fast := func(_ context.Context) Result[string] {
+		return Result[string]{Ok: "fast"}
+	}
+	failing := func(_ context.Context) Result[string] {
+		return Result[string]{Err: errors.New("failed")}
+	}
+	start := time.Now()
+	if res := Race(context.Background(), failing, slow, fast); res.IsErr() || res.Ok != "fast" {
+		t.Fatalf("first successful result should be returned %+v", res)
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Fatal("Race should not wait for the slow function")
+	}
+}
+
+func TestRaceAllFail(t *testing.T) {
+	firstErr := errors.New("first")
+	secondErr := errors.New("second")
+	res := Race(context.Background(),
+		func(_ context.Context) Result[int] { return Result[int]{Err: firstErr} },
+		func(_ context.Context) Result[int] { return Result[int]{Err: secondErr} })
+	if !errors.Is(res.Err, firstErr) || !errors.Is(res.Err, secondErr) {
+		t.Fatalf("all errors should be joined %+v", res)
+	}
+}
+
+func TestRaceContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	res := Race(ctx, func(_ context.Context) Result[int] {
+		time.Sleep(time.Second)
+		return Result[int]{Ok: 1}
+	})
+	if !errors.Is(res.Err, context.DeadlineExceeded) {
+		t.Fatalf("result should contain the context error %+v", res)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	res := WithTimeout(time.Second, func() Result[int] { return doDivide(4, 2) })
+	if res.IsErr() || res.Ok != 2 {
+		t.Fatalf("result of f should be returned %+v", res)
+	}
+	res = WithTimeout(10*time.Millisecond, func() Result[int] {
+		time.Sleep(time.Second)
+		return Result[int]{Ok: 1}
+	})
+	if !errors.Is(res.Err, ErrTimeout) {
+		t.Fatalf("result should contain ErrTimeout %+v", res)
+	}
+}
+
+func TestWithDeadline(t *testing.T) {
+	res := WithDeadline(context.Background(), func(_ context.Context) Result[int] {
+		return doDivide(4, 2)
+	})
+	if res.IsErr() || res.Ok != 2 {
+		t.Fatalf("result of f should be returned %+v", res)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	stopped := make(chan struct{})
+	res = WithDeadline(ctx, func(ctx context.Context) Result[int] {
+		<-ctx.Done()
+		close(stopped)
+		return Result[int]{Err: ctx.Err()}
+	})
+	if !errors.Is(res.Err, context.DeadlineExceeded) {
+		t.Fatalf("result should contain the context error %+v", res)
+	}
+	<-stopped
+}
@@ -0,0 +1,134 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"context"
+	"errors"
+)
+
+// EhContext is like Eh but it also checks if ctx is done. If the context is
+// done then it panics with the context error, even if the result contains an
+// error, so that cancellation propagates through escape hatch functions.
+// Otherwise it behaves exactly like calling Eh() on the result.
+//
+// Example:
+//
+//	func example(ctx context.Context, url string) (res eh.Result[[]byte]) {
+//		defer eh.EscapeHatch(&res)
+//		resp := eh.EhContext(ctx, eh.NewResult(fetch(ctx, url)))
+//		...
+//	}
+func EhContext[T any](ctx context.Context, r Result[T]) T {
+	if err := ctx.Err(); err != nil {
+		escape(err, "")
+	}
+	if r.Err != nil {
+		escape(r.Err, "")
+	}
+	return r.Ok
+}
+
+// EhCtx is like Eh but once the result is known to be Ok it also checks if
+// ctx is done, in which case it panics with the context error. Unlike
+// EhContext, the error of the result takes precedence over the context
+// error, so cancellation is only reported as the reason for escaping when
+// the step itself succeeded.
+func EhCtx[T any](r Result[T], ctx context.Context) T {
+	if r.Err != nil {
+		escape(r.Err, "")
+	}
+	if err := ctx.Err(); err != nil {
+		escape(err, "")
+	}
+	return r.Ok
+}
+
+// errorTagsKey is the context key of the tags added with WithErrorTag.
+type errorTagsKey struct{}
+
+// WithErrorTag returns a copy of ctx that carries the tag key with the value
+// val. The tags of a context are attached to the errors escaping through
+// EhC with that context, for example to correlate errors with a request ID.
+// A tag added later with the same key replaces the earlier value.
+func WithErrorTag(ctx context.Context, key, val string) context.Context {
+	parent, _ := ctx.Value(errorTagsKey{}).(map[string]string)
+	tags := make(map[string]string, len(parent)+1)
+	for k, v := range parent {
+		tags[k] = v
+	}
+	tags[key] = val
+	return context.WithValue(ctx, errorTagsKey{}, tags)
+}
+
+// EhC is like Eh but the escaping error is annotated with the tags added to
+// ctx with WithErrorTag, which can be retrieved from the recovered error with
+// TagsOf. The message of the error is not changed.
+//
+// Example:
+//
+//	func handle(ctx context.Context, id string) (res eh.Result[User]) {
+//		defer eh.EscapeHatch(&res)
+//		user := eh.EhC(ctx, findUser(id))
+//		...
+//	}
+//
+//	ctx = eh.WithErrorTag(ctx, "request_id", requestID)
+//	res := handle(ctx, id)
+//	log.Println(res.Err, eh.TagsOf(res.Err))
+func EhC[T any](ctx context.Context, r Result[T]) T {
+	if r.Err != nil {
+		err := r.Err
+		if tags, _ := ctx.Value(errorTagsKey{}).(map[string]string); len(tags) > 0 {
+			err = &taggedError{err: err, tags: tags}
+		}
+		escape(err, "")
+	}
+	return r.Ok
+}
+
+// TagsOf returns the tags that were attached to err by EhC. If the error
+// escaped several times with different tags they are merged, and the tags
+// attached last take precedence. It returns nil if err has no tags.
+func TagsOf(err error) map[string]string {
+	var tags map[string]string
+	var te *taggedError
+	for errors.As(err, &te) {
+		for k, v := range te.tags {
+			if _, ok := tags[k]; !ok {
+				if tags == nil {
+					tags = make(map[string]string)
+				}
+				tags[k] = v
+			}
+		}
+		err = te.err
+	}
+	return tags
+}
+
+// taggedError annotates an escaped error with the tags of a context.
+type taggedError struct {
+	err  error
+	tags map[string]string
+}
+
+func (e *taggedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *taggedError) Unwrap() error {
+	return e.err
+}
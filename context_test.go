@@ -0,0 +1,98 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func doDivideContext(ctx context.Context, x int, y int) (res Result[int]) {
+	defer EscapeHatch(&res)
+	return Result[int]{Ok: EhContext(ctx, NewResult(divide(x, y)))}
+}
+
+func TestEhContext(t *testing.T) {
+	res := doDivideContext(context.Background(), 4, 2)
+	if res.IsErr() || res.Ok != 2 {
+		t.Fatalf("result should be Ok %+v", res)
+	}
+	res = doDivideContext(context.Background(), 4, 0)
+	if res.IsOk() || res.Err.Error() != "divide by zero" {
+		t.Fatalf("result should contain the divide error %+v", res)
+	}
+}
+
+func TestEhContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	res := doDivideContext(ctx, 4, 2)
+	if !errors.Is(res.Err, context.Canceled) {
+		t.Fatalf("result should contain the context error %+v", res)
+	}
+	res = doDivideContext(ctx, 4, 0)
+	if !errors.Is(res.Err, context.Canceled) {
+		t.Fatalf("context error should take precedence %+v", res)
+	}
+}
+
+func TestEhCtx(t *testing.T) {
+	divideCtx := func(ctx context.Context, x int, y int) (res Result[int]) {
+		defer EscapeHatch(&res)
+		return Result[int]{Ok: EhCtx(NewResult(divide(x, y)), ctx)}
+	}
+	if res := divideCtx(context.Background(), 4, 2); res.IsErr() || res.Ok != 2 {
+		t.Fatalf("result should be Ok %+v", res)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if res := divideCtx(ctx, 4, 2); !errors.Is(res.Err, context.Canceled) {
+		t.Fatalf("result should contain the context error %+v", res)
+	}
+	if res := divideCtx(ctx, 4, 0); res.Err.Error() != "divide by zero" {
+		t.Fatalf("result error should take precedence %+v", res)
+	}
+}
+
+func TestEhC(t *testing.T) {
+	inner := func(ctx context.Context, y int) (res Result[int]) {
+		defer EscapeHatch(&res)
+		return Result[int]{Ok: EhC(ctx, doDivide(4, y))}
+	}
+	outer := func(ctx context.Context, y int) (res Result[int]) {
+		defer EscapeHatch(&res)
+		ctx = WithErrorTag(ctx, "step", "outer")
+		return Result[int]{Ok: EhC(ctx, inner(ctx, y))}
+	}
+	ctx := WithErrorTag(context.Background(), "request_id", "42")
+	ctx = WithErrorTag(ctx, "step", "inner")
+	if res := outer(ctx, 2); res.IsErr() || res.Ok != 2 {
+		t.Fatalf("Ok result should be unchanged %+v", res)
+	}
+	res := inner(ctx, 0)
+	if res.Err.Error() != "divide by zero" {
+		t.Fatalf("message should not be changed %+v", res)
+	}
+	if tags := TagsOf(res.Err); len(tags) != 2 || tags["request_id"] != "42" || tags["step"] != "inner" {
+		t.Fatalf("tags of the context should be attached %v", tags)
+	}
+	if tags := TagsOf(outer(ctx, 0).Err); tags["step"] != "outer" || tags["request_id"] != "42" {
+		t.Fatalf("tags attached last should take precedence %v", tags)
+	}
+	if tags := TagsOf(doDivide(1, 0).Err); tags != nil {
+		t.Fatalf("untagged error should have no tags %v", tags)
+	}
+}
@@ -17,6 +17,9 @@ package eh
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
+	"slices"
 )
 
 // Result represents a struct that contains an error in the Err field
@@ -33,6 +36,32 @@ func NewResult[T any](val T, err error) Result[T] {
 	return Result[T]{val, err}
 }
 
+// Must returns val if err is nil and panics with err otherwise. It is meant
+// for failures that the program cannot recover from, such as errors during
+// initialization, in the same way as template.Must. Unlike Eh, the panic is
+// not recovered by EscapeHatch.
+//
+// Example:
+//
+//	var config = eh.Must(loadConfig("config.yaml"))
+func Must[T any](val T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// NewResultOr is like NewResult but when err is not nil the Ok field is set
+// to def instead of val, so that the Ok value is meaningful even when there
+// is an error. Note that Eh still escapes with the error, the default is only
+// used by code that reads the Ok field directly.
+func NewResultOr[T any](val T, err error, def T) Result[T] {
+	if err != nil {
+		return Result[T]{def, err}
+	}
+	return Result[T]{val, nil}
+}
+
 // FromFailable creates a Result from an error. This is useful when you need to
 // execute a function that doesn't return a value but may result in failure.
 //
@@ -46,19 +75,169 @@ func NewResult[T any](val T, err error) Result[T] {
 //
 //		return eh.Result[string]{Ok: data}
 //	}
+//
+// Use FromFailableT when the Result should have a specific type.
 func FromFailable(err error) Result[any] {
-	return Result[any]{0, err}
+	return Result[any]{Err: err}
+}
+
+// FromFailableT is like FromFailable but it creates a Result of type T whose
+// Ok value is the zero value of T.
+//
+// Example:
+//
+//	func example(raw []byte) (r eh.Result[string]) {
+//		defer eh.EscapeHatch(&r)
+//
+//		var data string
+//		eh.FromFailableT[string](json.Unmarshal(raw, &data)).Eh()
+//
+//		return eh.Result[string]{Ok: data}
+//	}
+func FromFailableT[T any](err error) Result[T] {
+	return Result[T]{Err: err}
+}
+
+// FromBool creates a Result from the outcome of a check. The Result is Ok
+// when ok is true, in which case err is ignored, and contains err otherwise.
+func FromBool(ok bool, err error) Result[struct{}] {
+	return FromBoolVal(ok, struct{}{}, err)
+}
+
+// FromBoolVal is like FromBool but the Result holds val when ok is true.
+//
+// Example:
+//
+//	n := eh.FromBoolVal(x > 0, x, ErrNonPositive).Eh()
+func FromBoolVal[T any](ok bool, val T, err error) Result[T] {
+	if !ok {
+		return Result[T]{Err: err}
+	}
+	return Result[T]{Ok: val}
+}
+
+// Empty creates a Result that represents an absent value, its error is ErrNull.
+func Empty[T any]() Result[T] {
+	return Result[T]{Err: ErrNull}
+}
+
+// TryConvert creates a Result from the type assertion x.(T). The Result is Ok
+// when x holds a T and contains an error naming both types otherwise,
+// including when x is nil.
+//
+// Example:
+//
+//	name := eh.TryConvert[string](claims["name"]).Eh()
+func TryConvert[T any](x any) Result[T] {
+	if v, ok := x.(T); ok {
+		return Result[T]{Ok: v}
+	}
+	if x == nil {
+		return Result[T]{Err: fmt.Errorf("cannot convert nil to %v", reflect.TypeFor[T]())}
+	}
+	return Result[T]{Err: fmt.Errorf("cannot convert %T to %v", x, reflect.TypeFor[T]())}
+}
+
+// CastResult converts a Result created by AsAny back to a Result of type T.
+// The error is kept if there is one, otherwise the Ok value is converted with
+// TryConvert and the Result contains an error if it does not hold a T. A nil
+// Ok value is converted to the zero value of T when T is an interface type,
+// since that is what AsAny produces for a nil interface value.
+func CastResult[T any](r Result[any]) Result[T] {
+	if r.Err != nil {
+		return Result[T]{Err: r.Err}
+	}
+	var zero T
+	if r.Ok == nil && any(zero) == nil {
+		return Result[T]{}
+	}
+	return TryConvert[T](r.Ok)
 }
 
 // Eh checks if there is an error in the result and if so then it will
 // panic with the error that was encountered. If there is no error the Ok value is returned.
 func (r Result[T]) Eh() T {
 	if r.Err != nil {
-		panic(ehError{r.Err})
+		escape(r.Err, "")
+	}
+	return r.Ok
+}
+
+// EhCode is like Eh but it attaches code to the error when escaping. The code
+// is preserved by EscapeHatch and can be retrieved from the recovered error
+// with CodeOf, for example to classify errors in metrics.
+//
+// Example:
+//
+//	func example(aFile string) (res eh.Result[[]byte]) {
+//		defer eh.EscapeHatch(&res)
+//		file := eh.NewResult(os.Open(aFile)).EhCode("config_open")
+//		...
+//	}
+func (r Result[T]) EhCode(code string) T {
+	if r.Err != nil {
+		escape(r.Err, code)
 	}
 	return r.Ok
 }
 
+// EhWith is like Eh but the error is transformed with wrap before escaping,
+// for example to add context to a single step. The function wrap is only
+// called if there is an error. If wrap returns nil the original error is
+// used, so that an errored result always escapes with an error.
+//
+// Example:
+//
+//	file := eh.NewResult(os.Open(path)).EhWith(func(err error) error {
+//		return fmt.Errorf("config: %w", err)
+//	})
+func (r Result[T]) EhWith(wrap func(error) error) T {
+	if r.Err != nil {
+		err := wrap(r.Err)
+		if err == nil {
+			err = r.Err
+		}
+		escape(err, "")
+	}
+	return r.Ok
+}
+
+// Or is a panic free alternative to Eh. If there is an error it is stored in
+// err, but only if err does not already contain an error, and the zero value
+// of T is returned. If there is no error the Ok value is returned. This way
+// the first error wins and later calls to Or do not overwrite it, so the
+// function can be written without checking every step and the error is
+// checked once at the end.
+//
+// Example:
+//
+//	func example(aFile string) (buff []byte, err error) {
+//		buff = make([]byte, 5)
+//		file := eh.NewResult(os.Open(aFile)).Or(&err)
+//		if err != nil {
+//			return nil, err
+//		}
+//		_ = eh.NewResult(file.Read(buff)).Or(&err)
+//		return buff, err
+//	}
+func (r Result[T]) Or(err *error) T {
+	if r.Err != nil {
+		if *err == nil {
+			*err = r.Err
+		}
+		var zero T
+		return zero
+	}
+	return r.Ok
+}
+
+// AsAny returns the result as a Result[any] so that Results of different
+// types can be stored together, for example for logging. CastResult converts
+// it back.
+func (r Result[T]) AsAny() Result[any] {
+	return Result[any]{Ok: r.Ok, Err: r.Err}
+}
+
 // IsOk returns true when result has no error and otherwise false
 func (r Result[T]) IsOk() bool {
 	return r.Err == nil
@@ -69,6 +248,15 @@ func (r Result[T]) IsErr() bool {
 	return r.Err != nil
 }
 
+// String renders the result as `Ok(<value>)` when there is no error and as
+// `Err(<error message>)` otherwise.
+func (r Result[T]) String() string {
+	if r.Err != nil {
+		return fmt.Sprintf("Err(%v)", r.Err)
+	}
+	return fmt.Sprintf("Ok(%v)", r.Ok)
+}
+
 // MustUnwrap returns the Ok value or panics if there is an error.
 func (r Result[T]) MustUnwrap() T {
 	if r.Err != nil {
@@ -85,11 +273,241 @@ func (r Result[T]) MustUnwrapErr() error {
 	return r.Err
 }
 
+// Expect returns the Ok value or panics with the error wrapped with msg if
+// there is an error.
+func (r Result[T]) Expect(msg string) T {
+	if r.Err != nil {
+		panic(fmt.Errorf("%s: %w", msg, r.Err))
+	}
+	return r.Ok
+}
+
+// ExpectErr returns the Err value or panics with msg if there is no error.
+func (r Result[T]) ExpectErr(msg string) error {
+	if r.Err == nil {
+		panic(msg)
+	}
+	return r.Err
+}
+
+// UnwrapOr returns the Ok value or def if there is an error.
+func (r Result[T]) UnwrapOr(def T) T {
+	if r.Err != nil {
+		return def
+	}
+	return r.Ok
+}
+
+// UnwrapOrElse returns the Ok value or computes one from the error with f.
+// The function f is only called if there is an error.
+func (r Result[T]) UnwrapOrElse(f func(error) T) T {
+	if r.Err != nil {
+		return f(r.Err)
+	}
+	return r.Ok
+}
+
+// UnwrapOrDefault returns the Ok value or the zero value of T if there is an error.
+func (r Result[T]) UnwrapOrDefault() T {
+	if r.Err != nil {
+		var zero T
+		return zero
+	}
+	return r.Ok
+}
+
+// OkOrZero is the same as UnwrapOrDefault. It returns the Ok value or the
+// zero value of T if there is an error, and makes it explicit that the error
+// is not needed.
+func (r Result[T]) OkOrZero() T {
+	return r.UnwrapOrDefault()
+}
+
+// Ignore deliberately discards the error of r and returns the Ok value, or
+// the zero value of T on failure. It never panics. Use it instead of reading
+// r.Ok directly so that dropping the error stands out as intentional.
+func Ignore[T any](r Result[T]) T {
+	return r.UnwrapOrDefault()
+}
+
+// OrResult returns the result if there is no error and other otherwise.
+func (r Result[T]) OrResult(other Result[T]) Result[T] {
+	if r.Err != nil {
+		return other
+	}
+	return r
+}
+
+// AndResult returns other if there is no error and the result otherwise.
+func (r Result[T]) AndResult(other Result[T]) Result[T] {
+	if r.Err != nil {
+		return r
+	}
+	return other
+}
+
+// IfOk calls f with the Ok value if there is no error and returns the result
+// unchanged, so that calls can be chained.
+//
+// Example:
+//
+//	res.IfErr(logError).IfOk(cache.Store)
+func (r Result[T]) IfOk(f func(T)) Result[T] {
+	if r.Err == nil {
+		f(r.Ok)
+	}
+	return r
+}
+
+// IfErr calls f with the error if there is one and returns the result
+// unchanged, so that calls can be chained.
+func (r Result[T]) IfErr(f func(error)) Result[T] {
+	if r.Err != nil {
+		f(r.Err)
+	}
+	return r
+}
+
+// Tee stores the Ok value in dst if there is no error and returns the result
+// unchanged, so that an intermediate value can be captured in a chain of
+// calls. If there is an error dst is not modified.
+//
+// Example:
+//
+//	var user User
+//	res := eh.Filter(findUser(id).Tee(&user), User.IsActive, ErrInactive)
+func (r Result[T]) Tee(dst *T) Result[T] {
+	if r.Err == nil {
+		*dst = r.Ok
+	}
+	return r
+}
+
+// Recover returns a Result with v as the Ok value if the result has an error
+// that matches one of the errors in when, as reported by errors.Is, and the
+// result unchanged otherwise. If no errors are specified any error is
+// replaced. It is the counterpart of Fallback for code that does not use an
+// escape hatch.
+//
+// Example:
+//
+//	data := eh.NewResult(os.ReadFile(path)).Recover(nil, fs.ErrNotExist).Eh()
+func (r Result[T]) Recover(v T, when ...error) Result[T] {
+	catchError(&r, func(error) T { return v }, when)
+	return r
+}
+
+// WithOk returns a Result with v as the Ok value if there is no error and the
+// result unchanged otherwise. The result itself is not modified.
+func (r Result[T]) WithOk(v T) Result[T] {
+	if r.Err != nil {
+		return r
+	}
+	return Result[T]{Ok: v}
+}
+
+// WithErr returns a Result with err as the error, keeping the Ok value. The
+// result itself is not modified.
+func (r Result[T]) WithErr(err error) Result[T] {
+	return Result[T]{Ok: r.Ok, Err: err}
+}
+
+// Clone returns a copy of the result. If there is no error the Ok value of
+// the copy is deep(r.Ok), which allows copying a payload such as a pointer
+// or a slice so that the copy does not alias the original. If deep is nil or
+// the result is errored a shallow copy is returned. The error is never
+// copied, both results share it by reference.
+func (r Result[T]) Clone(deep func(T) T) Result[T] {
+	if r.Err != nil || deep == nil {
+		return r
+	}
+	return Result[T]{Ok: deep(r.Ok)}
+}
+
+// OkPtr returns a pointer to a copy of the Ok value if there is no error and
+// nil otherwise.
+func (r Result[T]) OkPtr() *T {
+	if r.Err != nil {
+		return nil
+	}
+	return &r.Ok
+}
+
+// ErrPtr returns a pointer to a copy of the error if there is one and nil
+// otherwise.
+func (r Result[T]) ErrPtr() *error {
+	if r.Err == nil {
+		return nil
+	}
+	return &r.Err
+}
+
+// OkSlice returns a slice that contains the Ok value if there is no error and
+// an empty slice otherwise. The returned slice is never nil.
+func (r Result[T]) OkSlice() []T {
+	if r.Err != nil {
+		return []T{}
+	}
+	return []T{r.Ok}
+}
+
+// ErrSlice returns a slice that contains the error if there is one and an
+// empty slice otherwise. The returned slice is never nil.
+func (r Result[T]) ErrSlice() []error {
+	if r.Err == nil {
+		return []error{}
+	}
+	return []error{r.Err}
+}
+
+// Context adds context to the error of the result by wrapping it with a
+// formatted message, the original error remains available through
+// errors.Unwrap. If there is no error the result is returned unchanged.
+//
+// Example:
+//
+//	file := eh.NewResult(os.Open(path)).Context("opening config %q", path).Eh()
+func (r Result[T]) Context(format string, args ...any) Result[T] {
+	if r.Err == nil {
+		return r
+	}
+	return Result[T]{Ok: r.Ok, Err: fmt.Errorf(format+": %w", append(args, r.Err)...)}
+}
+
 // Unwrap method returns a value and an error
 func (r Result[T]) Unwrap() (T, error) {
 	return r.Ok, r.Err
 }
 
+// Get returns the Ok value and true if there is no error, and the zero value
+// of T and false otherwise, in the same way as accessing a map.
+//
+// Example:
+//
+//	if v, ok := res.Get(); ok {
+//		...
+//	}
+func (r Result[T]) Get() (T, bool) {
+	if r.Err != nil {
+		var zero T
+		return zero, false
+	}
+	return r.Ok, true
+}
+
+// Fields returns the Ok value, the error and whether the result is Ok in a
+// single call. The Ok value is returned as is even if there is an error.
+func (r Result[T]) Fields() (ok T, err error, isOk bool) {
+	return r.Ok, r.Err, r.Err == nil
+}
+
+// Return is the same as the Unwrap method. It makes the point where a Result
+// is converted back to the standard value and error pair explicit, usually
+// in the return statement of a function with a regular Go signature.
+func (r Result[T]) Return() (T, error) {
+	return r.Ok, r.Err
+}
+
 // Unwrap function unwraps a Result into a value and an error, making
 // it useful for implementing inline callbacks.
 //
@@ -105,27 +523,142 @@ func Unwrap[T any](r Result[T]) (T, error) {
 	return r.Ok, r.Err
 }
 
+// Equal reports whether two Results have equal Ok values and equivalent
+// errors. The errors are equivalent when both are nil or when the error of a
+// matches the error of b according to errors.Is.
+func Equal[T comparable](a, b Result[T]) bool {
+	if a.Ok != b.Ok {
+		return false
+	}
+	if a.Err == nil || b.Err == nil {
+		return a.Err == b.Err
+	}
+	return errors.Is(a.Err, b.Err)
+}
+
 // ehError is used to wrap any errors that are raised because of calling
 // ReturnIfErr on a Result.
 type ehError struct {
 	error
+	stack  []uintptr
+	code   string
+	strict bool
+}
+
+// Error returns the message of the escaping error. In strict mode the
+// message points out that an escape hatch is probably missing, since it is
+// only seen when the panic is not recovered.
+func (e ehError) Error() string {
+	if e.strict {
+		return "eh: error escaped without an escape hatch, is a deferred eh.EscapeHatch missing? " + e.error.Error()
+	}
+	return e.error.Error()
+}
+
+// cause returns the error that EscapeHatch should store in the Result. This is
+// the original error unless extra information was captured when escaping.
+func (e ehError) cause() error {
+	if e.stack == nil && e.code == "" && !tagEscaped.Load() {
+		return e.error
+	}
+	return &escapedError{err: e.error, stack: e.stack, code: e.code}
+}
+
+// codeOf returns the code attached to the escaping error, or the code that
+// was attached when the error escaped before.
+func (e ehError) codeOf() string {
+	if e.code != "" {
+		return e.code
+	}
+	code, _ := CodeOf(e.error)
+	return code
+}
+
+// escape panics with an ehError wrapping err so that it can be recovered by
+// EscapeHatch, attaching code to it if it is not empty. It has to be called
+// directly by the method or function that the user called so that a captured
+// stack starts at the user's code.
+func escape(err error, code string) {
+	if autoWrap.Load() && !hasCaller(err) {
+		err = &callerError{caller: callerLocation(2), err: err}
+	}
+	err = withPrefix(err)
+	e := ehError{error: err, code: code, strict: strictMode.Load()}
+	if captureStack.Load() && !hasStackTrace(err) {
+		e.stack = callers(2)
+	}
+	panic(e)
 }
 
 // EscapeHatch will recover from a panic that was raised from any error
 // raised from the error checks performed by eh. The recovered error is
 // populated in the Result pointed by the res pointer. If the recovered
-// error was not raised by eh then the same panic will be raised.
+// error was not raised by eh then the same panic will be raised with the
+// original panic value. The panic is raised again from within the deferred
+// call, before the stack is unwound, so the traceback still shows where the
+// original panic happened.
 func EscapeHatch[T any](res *Result[T]) {
+	if r := recover(); r != nil {
+		*res = Result[T]{Err: recovered(r)}
+	}
+}
+
+// EscapeHatchCode is like EscapeHatch but it only recovers errors that
+// escaped with one of the given codes through EhCode. Errors with other codes
+// or without a code are raised again so that they propagate to the escape
+// hatch of the calling function. If no codes are given it behaves exactly
+// like EscapeHatch.
+//
+// Example:
+//
+//	func example() (res eh.Result[string]) {
+//		defer eh.EscapeHatchCode(&res, "not_found")
+//		// only recovered when the error is not found, other errors propagate
+//		data := eh.NewResult(find()).EhCode("not_found")
+//		...
+//	}
+func EscapeHatchCode[T any](res *Result[T], codes ...string) {
 	if r := recover(); r != nil {
 		err, ok := r.(ehError)
-		if !ok {
-			// Panicking again because the recovered panic is not an ehError
+		if ok && len(codes) > 0 && !slices.Contains(codes, err.codeOf()) {
 			panic(r)
 		}
-		*res = Result[T]{Err: err.error}
+		*res = Result[T]{Err: recovered(r)}
 	}
 }
 
+// EscapeHatchAll is like EscapeHatch but it recovers from any panic, not
+// only from the errors raised by eh. Panics that were not raised by eh are
+// stored in the Result as an error of the form `recovered panic: <value>`.
+//
+// Use this deliberately, for example at the top of a request handler that
+// must never crash the server. It swallows programmer bugs such as nil
+// pointer dereferences that would otherwise crash the program, and the stack
+// of the original panic is lost.
+func EscapeHatchAll[T any](res *Result[T]) {
+	if r := recover(); r != nil {
+		if err, ok := r.(ehError); ok {
+			*res = Result[T]{Err: err.cause()}
+			return
+		}
+		*res = Result[T]{Err: fmt.Errorf("recovered panic: %v", r)}
+	}
+}
+
+// recovered returns the error carried by a value recovered from a panic
+// raised by eh. If the value was not raised by eh then the same panic will
+// be raised. All the deferred handlers recover directly and call recovered
+// so that a foreign panic is never raised again more than once per handler.
+func recovered(r any) error {
+	err, ok := r.(ehError)
+	if !ok {
+		// Panicking again because the recovered panic is not an ehError
+		notifyUnexpectedPanic(r)
+		panic(r)
+	}
+	return err.cause()
+}
+
 // EscapeHatchErr is similarly to the `EscapeHatch`, with the difference
 // that it is designed for implementing methods that must conform to return
 // signatures of `(value, error)` or `(error)`, such as overriding a method
@@ -141,13 +674,8 @@ func EscapeHatch[T any](res *Result[T]) {
 //		return successVal, nil
 //	}
 func EscapeHatchErr(err *error) {
-	res := FromFailable(*err)
-	defer func() {
-		_, *err = res.Unwrap()
-	}()
-	defer EscapeHatch(&res)
 	if r := recover(); r != nil {
-		panic(r)
+		*err = recovered(r)
 	}
 }
 
@@ -178,29 +706,32 @@ func EscapeHatchErr(err *error) {
 //			}
 //	}
 func CatchError[T any](res *Result[T], catcher func(error) T, when ...error) {
-	defer func() {
-		if res.IsOk() {
-			return
-		}
+	if r := recover(); r != nil {
+		*res = Result[T]{Err: recovered(r)}
+	}
+	catchError(res, catcher, when)
+}
 
-		err := res.MustUnwrapErr()
-		// Passing nil `when` means use orElse for any error
-		if when == nil {
-			*res = Result[T]{Ok: catcher(err)}
-			return
-		}
-		for _, target := range when {
-			if !errors.Is(err, target) {
-				continue
-			}
-			// Use fallback value if the result error matches the target error, otherwise leave the result untouched
-			*res = Result[T]{Ok: catcher(err)}
-			break
+// catchError replaces the error in res with the value returned by catcher
+// if the error matches any of the errors in when.
+func catchError[T any](res *Result[T], catcher func(error) T, when []error) {
+	if res.IsOk() {
+		return
+	}
+
+	err := res.MustUnwrapErr()
+	// Passing nil `when` means use orElse for any error
+	if when == nil {
+		*res = Result[T]{Ok: catcher(err)}
+		return
+	}
+	for _, target := range when {
+		if !errors.Is(err, target) {
+			continue
 		}
-	}()
-	defer EscapeHatch(res)
-	if r := recover(); r != nil {
-		panic(r)
+		// Use fallback value if the result error matches the target error, otherwise leave the result untouched
+		*res = Result[T]{Ok: catcher(err)}
+		break
 	}
 }
 
@@ -229,8 +760,97 @@ func CatchError[T any](res *Result[T], catcher func(error) T, when ...error) {
 //			}
 //	}
 func Fallback[T any](res *Result[T], fallback T, when ...error) {
-	defer CatchError(res, func(_ error) T { return fallback }, when...)
 	if r := recover(); r != nil {
-		panic(r)
+		*res = Result[T]{Err: recovered(r)}
+	}
+	catchError(res, func(_ error) T { return fallback }, when)
+}
+
+// FallbackWith is like Fallback but the fallback value is computed by calling
+// f, which only happens when a matching error occurred. This avoids computing
+// an expensive fallback value when there is no error.
+//
+// Example:
+//
+//	func Example() (r eh.Result[Config]) {
+//		defer eh.EscapeHatch(&r)
+//		defer eh.FallbackWith(&r, loadDefaultConfig, ErrConfigNotFound)
+//		return eh.Result[Config]{Ok: eh.NewResult(loadConfig()).Eh()}
+//	}
+func FallbackWith[T any](res *Result[T], f func() T, when ...error) {
+	if r := recover(); r != nil {
+		*res = Result[T]{Err: recovered(r)}
 	}
+	catchError(res, func(_ error) T { return f() }, when)
+}
+
+// CatchErrorAs is like CatchError but it matches the error by type instead of
+// by value. If the error matches the type E according to errors.As then
+// catcher is called with the typed error, otherwise the result is left
+// untouched for later handlers.
+//
+// Example:
+//
+//	func Example(name string) (r eh.Result[[]byte]) {
+//		defer eh.EscapeHatch(&r)
+//		defer eh.CatchErrorAs(&r, func(err *fs.PathError) []byte {
+//			return eh.NewResult(os.ReadFile(filepath.Join(fallbackDir, name))).Eh()
+//		})
+//		return eh.NewResult(os.ReadFile(name))
+//	}
+func CatchErrorAs[T any, E error](res *Result[T], catcher func(E) T) {
+	if r := recover(); r != nil {
+		*res = Result[T]{Err: recovered(r)}
+	}
+	catchErrorAs(res, catcher)
+}
+
+// catchErrorAs replaces the error in res with the value returned by catcher
+// if the error matches the type E.
+func catchErrorAs[T any, E error](res *Result[T], catcher func(E) T) {
+	if res.IsOk() {
+		return
+	}
+	var target E
+	if errors.As(res.Err, &target) {
+		*res = Result[T]{Ok: catcher(target)}
+	}
+}
+
+// FallbackAs is like Fallback but the fallback value is only used when the
+// error matches the type E according to errors.As. Otherwise the result is
+// left untouched for later handlers.
+//
+// Example:
+//
+//	func Example() (r eh.Result[string]) {
+//		defer eh.EscapeHatch(&r)
+//		defer eh.FallbackAs[string, *url.Error](&r, "default value")
+//		return eh.Result[string]{Ok: eh.NewResult(fetch(endpoint)).Eh()}
+//	}
+func FallbackAs[T any, E error](res *Result[T], fallback T) {
+	if r := recover(); r != nil {
+		*res = Result[T]{Err: recovered(r)}
+	}
+	catchErrorAs(res, func(_ E) T { return fallback })
+}
+
+// Finally calls f with the current Result without modifying it. It is meant
+// to be deferred before EscapeHatch and any other handlers so that it runs
+// last, after the error recovery has settled, for example to close resources
+// or to emit metrics. Finally never recovers, so it also runs when the
+// function panics with a panic not raised by eh and lets that panic continue.
+//
+// Example:
+//
+//	func Example() (r eh.Result[string]) {
+//		defer eh.Finally(&r, func(res eh.Result[string]) {
+//			metrics.Record("example", res.IsOk())
+//		})
+//		defer eh.EscapeHatch(&r)
+//		defer eh.Fallback(&r, "default value", ErrNotFound)
+//		return eh.Result[string]{Ok: eh.NewResult(find()).Eh()}
+//	}
+func Finally[T any](res *Result[T], f func(Result[T])) {
+	f(*res)
 }
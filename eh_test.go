@@ -18,6 +18,10 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strings"
 	"sync"
 	"testing"
 )
@@ -342,3 +346,603 @@ func TestHandleAnyError(t *testing.T) {
 	}
 
 }
+
+func TestUnwrapOr(t *testing.T) {
+	if val := doDivide(4, 2).UnwrapOr(100); val != 2 {
+		t.Fatalf("UnwrapOr should return 2, got %d", val)
+	}
+	if val := doDivide(1, 0).UnwrapOr(100); val != 100 {
+		t.Fatalf("UnwrapOr should return 100, got %d", val)
+	}
+}
+
+func TestUnwrapOrElse(t *testing.T) {
+	val := doDivide(4, 2).UnwrapOrElse(func(_ error) int {
+		t.Fatal("f should not be called on an Ok result")
+		return 0
+	})
+	if val != 2 {
+		t.Fatalf("UnwrapOrElse should return 2, got %d", val)
+	}
+	val = doDivide(1, 0).UnwrapOrElse(func(err error) int {
+		return len(err.Error())
+	})
+	if val != len("divide by zero") {
+		t.Fatalf("UnwrapOrElse should compute the value from the error, got %d", val)
+	}
+}
+
+func TestUnwrapOrDefault(t *testing.T) {
+	if val := doDivide(4, 2).UnwrapOrDefault(); val != 2 {
+		t.Fatalf("UnwrapOrDefault should return 2, got %d", val)
+	}
+	res := Result[string]{Ok: "ignored", Err: fmt.Errorf("error")}
+	if val := res.UnwrapOrDefault(); val != "" {
+		t.Fatalf("UnwrapOrDefault should return the zero value, got %q", val)
+	}
+}
+
+func TestOkOrZero(t *testing.T) {
+	if val := doDivide(4, 2).OkOrZero(); val != 2 {
+		t.Fatalf("OkOrZero should return 2, got %d", val)
+	}
+	res := Result[string]{Ok: "ignored", Err: fmt.Errorf("error")}
+	if val := res.OkOrZero(); val != "" {
+		t.Fatalf("OkOrZero should return the zero value, got %q", val)
+	}
+	if val := Ignore(res); val != "" {
+		t.Fatalf("Ignore should return the zero value, got %q", val)
+	}
+	if val := Ignore(doDivide(4, 2)); val != 2 {
+		t.Fatalf("Ignore should return 2, got %d", val)
+	}
+}
+
+func TestEscapeHatchRepanicKeepsOrigin(t *testing.T) {
+	var data *struct{ val int }
+	crash := func() (r Result[int]) {
+		defer EscapeHatch(&r)
+		defer Fallback(&r, 100)
+		defer CatchError(&r, func(_ error) int { return 100 })
+		return Result[int]{Ok: data.val}
+	}
+	defer func() {
+		r := recover()
+		if _, ok := r.(runtime.Error); !ok {
+			t.Fatalf("original panic value should be preserved, got %#v", r)
+		}
+		if !strings.Contains(string(debug.Stack()), "TestEscapeHatchRepanicKeepsOrigin.func1(") {
+			t.Fatalf("stack should contain the function that panicked:\n%s", debug.Stack())
+		}
+	}()
+	crash()
+	t.Fatal("code should have panicked")
+}
+
+func TestEscapeHatchErrKeepsError(t *testing.T) {
+	aErr := errors.New("error")
+	returnErr := func() (err error) {
+		defer EscapeHatchErr(&err)
+		return aErr
+	}
+	if err := returnErr(); err != aErr {
+		t.Fatalf("returned error should not be touched, got %v", err)
+	}
+}
+
+func TestEscapeHatchAll(t *testing.T) {
+	var data *struct{ val int }
+	crash := func() (r Result[int]) {
+		defer EscapeHatchAll(&r)
+		return Result[int]{Ok: data.val}
+	}
+	res := crash()
+	if res.IsOk() || !strings.HasPrefix(res.Err.Error(), "recovered panic: ") {
+		t.Fatalf("panic should be converted into an error %+v", res)
+	}
+}
+
+func TestEscapeHatchAllEh(t *testing.T) {
+	doDivideAll := func(x int, y int) (r Result[int]) {
+		defer EscapeHatchAll(&r)
+		return Result[int]{Ok: NewResult(divide(x, y)).Eh()}
+	}
+	if res := doDivideAll(1, 0); res.Err.Error() != "divide by zero" {
+		t.Fatalf("escaped error should be unwrapped %+v", res)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	aErr := errors.New("error")
+	if !Equal(doDivide(4, 2), Result[int]{Ok: 2}) {
+		t.Fatal("Ok results with the same value should be equal")
+	}
+	if Equal(doDivide(4, 2), Result[int]{Ok: 3}) {
+		t.Fatal("Ok results with different values should not be equal")
+	}
+	if !Equal(Result[int]{Err: fmt.Errorf("wrapped: %w", aErr)}, Result[int]{Err: aErr}) {
+		t.Fatal("errored results with matching errors should be equal")
+	}
+	if Equal(Result[int]{Err: aErr}, Result[int]{Err: errors.New("error")}) {
+		t.Fatal("errored results with different errors should not be equal")
+	}
+	if Equal(Result[int]{}, Result[int]{Err: aErr}) {
+		t.Fatal("Ok and errored results should not be equal")
+	}
+}
+
+func TestString(t *testing.T) {
+	if s := doDivide(4, 2).String(); s != "Ok(2)" {
+		t.Fatalf("String should return Ok(2), got %q", s)
+	}
+	if s := fmt.Sprintf("%v", doDivide(1, 0)); s != "Err(divide by zero)" {
+		t.Fatalf("String should return Err(divide by zero), got %q", s)
+	}
+}
+
+func TestFallbackWith(t *testing.T) {
+	errNotFound := errors.New("not found")
+	calls := 0
+	fallback := func() int {
+		calls++
+		return 100
+	}
+	find := func(err error) (r Result[int]) {
+		defer EscapeHatch(&r)
+		defer FallbackWith(&r, fallback, errNotFound)
+		return Result[int]{Ok: NewResult(1, err).Eh()}
+	}
+	if res := find(nil); res.Ok != 1 || calls != 0 {
+		t.Fatalf("fallback should not be computed without an error %+v", res)
+	}
+	if res := find(errNotFound); res.Ok != 100 || calls != 1 {
+		t.Fatalf("fallback should be used for a matching error %+v", res)
+	}
+	if res := find(errors.New("other")); res.IsOk() || calls != 1 {
+		t.Fatalf("fallback should not be used for other errors %+v", res)
+	}
+}
+
+func TestCatchErrorAs(t *testing.T) {
+	read := func(name string) (r Result[[]byte]) {
+		defer EscapeHatch(&r)
+		defer CatchErrorAs(&r, func(err *os.PathError) []byte {
+			return []byte(err.Op)
+		})
+		return Result[[]byte]{Ok: NewResult(os.ReadFile(name)).Eh()}
+	}
+	if res := read("non-existing-file"); res.IsErr() || string(res.Ok) != "open" {
+		t.Fatalf("error should have been caught %+v", res)
+	}
+}
+
+func TestCatchErrorAsNoMatch(t *testing.T) {
+	caught := func() (r Result[int]) {
+		defer EscapeHatch(&r)
+		defer CatchErrorAs(&r, func(_ *os.PathError) int { return 100 })
+		return Result[int]{Ok: NewResult(divide(1, 0)).Eh()}
+	}
+	if res := caught(); res.IsOk() || res.Err.Error() != "divide by zero" {
+		t.Fatalf("error should not have been caught %+v", res)
+	}
+}
+
+func TestFallbackAs(t *testing.T) {
+	read := func(name string) (r Result[string]) {
+		defer EscapeHatch(&r)
+		defer FallbackAs[string, *os.PathError](&r, "default value")
+		return Result[string]{Ok: string(NewResult(os.ReadFile(name)).Eh())}
+	}
+	if res := read("non-existing-file"); res.IsErr() || res.Ok != "default value" {
+		t.Fatalf("fallback should be used %+v", res)
+	}
+	divideWithFallback := func() (r Result[int]) {
+		defer EscapeHatch(&r)
+		defer FallbackAs[int, *os.PathError](&r, 100)
+		return Result[int]{Ok: NewResult(divide(1, 0)).Eh()}
+	}
+	if res := divideWithFallback(); res.IsOk() {
+		t.Fatalf("fallback should not be used for other errors %+v", res)
+	}
+}
+
+func TestFinally(t *testing.T) {
+	var seen []Result[int]
+	divideFinally := func(x int, y int) (r Result[int]) {
+		defer Finally(&r, func(res Result[int]) { seen = append(seen, res) })
+		defer EscapeHatch(&r)
+		return Result[int]{Ok: NewResult(divide(x, y)).Eh()}
+	}
+	if res := divideFinally(4, 0); res.IsOk() {
+		t.Fatalf("Finally should not modify the result %+v", res)
+	}
+	divideFinally(4, 2)
+	if len(seen) != 2 || seen[0].Err == nil || seen[1].Ok != 2 {
+		t.Fatalf("f should be called with the settled results %+v", seen)
+	}
+}
+
+func TestFinallyPanic(t *testing.T) {
+	called := false
+	crash := func() (r Result[int]) {
+		defer Finally(&r, func(_ Result[int]) { called = true })
+		defer EscapeHatch(&r)
+		panic("boom")
+	}
+	defer func() {
+		if r := recover(); r != "boom" || !called {
+			t.Fatalf("f should be called and the panic should continue, got %v", r)
+		}
+	}()
+	crash()
+	t.Fatal("code should have panicked")
+}
+
+func TestContext(t *testing.T) {
+	res := doDivide(1, 0).Context("dividing %d by %d", 1, 0)
+	if res.Err.Error() != "dividing 1 by 0: divide by zero" {
+		t.Fatalf("error should be wrapped %+v", res)
+	}
+	if errors.Unwrap(res.Err).Error() != "divide by zero" {
+		t.Fatalf("original error should be unwrappable %+v", res)
+	}
+	if res := doDivide(4, 2).Context("dividing"); res.IsErr() || res.Ok != 2 {
+		t.Fatalf("Ok result should be unchanged %+v", res)
+	}
+}
+
+func TestFromFailableT(t *testing.T) {
+	if res := FromFailableT[string](nil); res.IsErr() || res.Ok != "" {
+		t.Fatalf("result should be Ok with the zero value %+v", res)
+	}
+	aErr := errors.New("error")
+	if res := FromFailableT[string](aErr); res.Err != aErr {
+		t.Fatalf("result should contain the error %+v", res)
+	}
+}
+
+func TestFromFailable(t *testing.T) {
+	if res := FromFailable(nil); res.IsErr() || res.Ok != nil {
+		t.Fatalf("result should be Ok with a nil value %+v", res)
+	}
+}
+
+func TestOr(t *testing.T) {
+	var err error
+	if val := doDivide(4, 2).Or(&err); val != 2 || err != nil {
+		t.Fatalf("Or should return 2 without an error, got %d %v", val, err)
+	}
+	firstErr := errors.New("first")
+	if val := (Result[int]{Ok: 1, Err: firstErr}).Or(&err); val != 0 || err != firstErr {
+		t.Fatalf("Or should return 0 and set the error, got %d %v", val, err)
+	}
+	_ = doDivide(1, 0).Or(&err)
+	if err != firstErr {
+		t.Fatalf("the first error should not be overwritten, got %v", err)
+	}
+}
+
+func TestOkPtr(t *testing.T) {
+	if ptr := doDivide(4, 2).OkPtr(); ptr == nil || *ptr != 2 {
+		t.Fatalf("OkPtr should point to 2, got %v", ptr)
+	}
+	if ptr := doDivide(1, 0).OkPtr(); ptr != nil {
+		t.Fatalf("OkPtr should be nil, got %v", ptr)
+	}
+}
+
+func TestErrPtr(t *testing.T) {
+	if ptr := doDivide(4, 2).ErrPtr(); ptr != nil {
+		t.Fatalf("ErrPtr should be nil, got %v", ptr)
+	}
+	if ptr := doDivide(1, 0).ErrPtr(); ptr == nil || (*ptr).Error() != "divide by zero" {
+		t.Fatalf("ErrPtr should point to the error, got %v", ptr)
+	}
+}
+
+func TestNewResultOr(t *testing.T) {
+	val, err := divide(4, 2)
+	if res := NewResultOr(val, err, 100); res.IsErr() || res.Ok != 2 {
+		t.Fatalf("result should contain the value %+v", res)
+	}
+	val, err = divide(1, 0)
+	res := NewResultOr(val, err, 100)
+	if res.IsOk() || res.Ok != 100 {
+		t.Fatalf("result should contain the default and the error %+v", res)
+	}
+	escaped := func() (r Result[int]) {
+		defer EscapeHatch(&r)
+		return Result[int]{Ok: res.Eh()}
+	}
+	if r := escaped(); r.IsOk() {
+		t.Fatalf("Eh should still escape with the error %+v", r)
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	find := func(key string) (r Result[string]) {
+		defer EscapeHatch(&r)
+		defer Fallback(&r, "default value", ErrNull)
+		if key == "" {
+			return Result[string]{Ok: Empty[string]().Eh()}
+		}
+		return Result[string]{Ok: NewResult("", errors.New("not found")).Eh()}
+	}
+	if res := find(""); res.IsErr() || res.Ok != "default value" {
+		t.Fatalf("ErrNull should be caught by Fallback %+v", res)
+	}
+	if res := find("key"); res.IsOk() {
+		t.Fatalf("other errors should not be caught %+v", res)
+	}
+}
+
+func TestMust(t *testing.T) {
+	if val := Must(divide(4, 2)); val != 2 {
+		t.Fatalf("Must should return 2, got %d", val)
+	}
+}
+
+func TestMustNotRecovered(t *testing.T) {
+	mustDivide := func() (r Result[int]) {
+		defer EscapeHatch(&r)
+		return Result[int]{Ok: Must(divide(1, 0))}
+	}
+	defer func() {
+		if r := recover(); r == nil || r.(error).Error() != "divide by zero" {
+			t.Fatalf("Must should panic with the error, got %v", r)
+		}
+	}()
+	mustDivide()
+	t.Fatal("EscapeHatch should not recover from Must")
+}
+
+func TestOrResult(t *testing.T) {
+	if res := doDivide(4, 2).OrResult(Result[int]{Ok: 100}); res.Ok != 2 {
+		t.Fatalf("result should be kept %+v", res)
+	}
+	if res := doDivide(1, 0).OrResult(Result[int]{Ok: 100}); res.IsErr() || res.Ok != 100 {
+		t.Fatalf("other should be returned %+v", res)
+	}
+}
+
+func TestAndResult(t *testing.T) {
+	if res := doDivide(4, 2).AndResult(Result[int]{Ok: 100}); res.Ok != 100 {
+		t.Fatalf("other should be returned %+v", res)
+	}
+	if res := doDivide(1, 0).AndResult(Result[int]{Ok: 100}); res.IsOk() {
+		t.Fatalf("error should be kept %+v", res)
+	}
+}
+
+func TestEhWith(t *testing.T) {
+	wrap := func(err error) error { return fmt.Errorf("dividing: %w", err) }
+	divideWith := func(x int, y int) (r Result[int]) {
+		defer EscapeHatch(&r)
+		return Result[int]{Ok: NewResult(divide(x, y)).EhWith(wrap)}
+	}
+	if res := divideWith(1, 0); res.Err.Error() != "dividing: divide by zero" {
+		t.Fatalf("escaped error should be wrapped %+v", res)
+	}
+	divideNilWrap := func() (r Result[int]) {
+		defer EscapeHatch(&r)
+		return Result[int]{Ok: NewResult(divide(1, 0)).EhWith(func(error) error { return nil })}
+	}
+	if res := divideNilWrap(); res.IsOk() || res.Err.Error() != "divide by zero" {
+		t.Fatalf("original error should escape when wrap returns nil %+v", res)
+	}
+	res := Result[int]{Ok: 1}
+	if val := res.EhWith(func(err error) error {
+		t.Fatal("wrap should not be called on an Ok result")
+		return err
+	}); val != 1 {
+		t.Fatalf("EhWith should return 1, got %d", val)
+	}
+}
+
+func TestExpect(t *testing.T) {
+	if val := doDivide(4, 2).Expect("dividing"); val != 2 {
+		t.Fatalf("Expect should return 2, got %d", val)
+	}
+	defer func() {
+		err, ok := recover().(error)
+		if !ok || err.Error() != "dividing: divide by zero" || errors.Unwrap(err) == nil {
+			t.Fatalf("Expect should panic with the wrapped error, got %v", err)
+		}
+	}()
+	_ = doDivide(1, 0).Expect("dividing")
+	t.Fatal("code should have panicked")
+}
+
+func TestExpectErr(t *testing.T) {
+	if err := doDivide(1, 0).ExpectErr("dividing"); err.Error() != "divide by zero" {
+		t.Fatalf("ExpectErr should return the error, got %v", err)
+	}
+	defer func() {
+		if r := recover(); r != "dividing should fail" {
+			t.Fatalf("ExpectErr should panic with the message, got %v", r)
+		}
+	}()
+	_ = doDivide(4, 2).ExpectErr("dividing should fail")
+	t.Fatal("code should have panicked")
+}
+
+func TestFromBool(t *testing.T) {
+	aErr := errors.New("error")
+	if res := FromBool(true, aErr); res.IsErr() {
+		t.Fatalf("result should be Ok %+v", res)
+	}
+	if res := FromBool(false, aErr); res.Err != aErr {
+		t.Fatalf("result should contain the error %+v", res)
+	}
+}
+
+func TestFromBoolVal(t *testing.T) {
+	aErr := errors.New("error")
+	if res := FromBoolVal(true, 1, aErr); res.IsErr() || res.Ok != 1 {
+		t.Fatalf("result should be Ok with 1 %+v", res)
+	}
+	if res := FromBoolVal(false, 1, aErr); res.Err != aErr || res.Ok != 0 {
+		t.Fatalf("result should contain the error %+v", res)
+	}
+}
+
+func TestIfOkIfErr(t *testing.T) {
+	var oks []int
+	var errs []error
+	for _, res := range []Result[int]{doDivide(4, 2), doDivide(1, 0)} {
+		chained := res.IfErr(func(err error) { errs = append(errs, err) }).
+			IfOk(func(v int) { oks = append(oks, v) })
+		if !Equal(chained, res) {
+			t.Fatalf("result should be unchanged %+v", chained)
+		}
+	}
+	if len(oks) != 1 || oks[0] != 2 || len(errs) != 1 || errs[0].Error() != "divide by zero" {
+		t.Fatalf("only the matching callback should be called %v %v", oks, errs)
+	}
+}
+
+func TestWithOk(t *testing.T) {
+	res := doDivide(4, 2)
+	if updated := res.WithOk(100); updated.IsErr() || updated.Ok != 100 || res.Ok != 2 {
+		t.Fatalf("Ok value should be replaced in a copy %+v %+v", updated, res)
+	}
+	if updated := doDivide(1, 0).WithOk(100); updated.IsOk() || updated.Ok != 0 {
+		t.Fatalf("errored result should be unchanged %+v", updated)
+	}
+}
+
+func TestWithErr(t *testing.T) {
+	aErr := errors.New("error")
+	res := doDivide(4, 2)
+	if updated := res.WithErr(aErr); updated.Err != aErr || res.IsErr() {
+		t.Fatalf("error should be set in a copy %+v %+v", updated, res)
+	}
+}
+
+func TestClone(t *testing.T) {
+	res := example("README.md")
+	deep := res.Clone(slices.Clone[[]byte])
+	deep.Ok[0] = 0
+	if res.Ok[0] == 0 {
+		t.Fatalf("deep copy should not alias the original %+v", res)
+	}
+	shallow := res.Clone(nil)
+	shallow.Ok[0] = 0
+	if res.Ok[0] != 0 {
+		t.Fatalf("shallow copy should alias the original %+v", res)
+	}
+	errRes := doDivide(1, 0)
+	if cloned := errRes.Clone(func(v int) int { return v + 1 }); cloned.Err != errRes.Err {
+		t.Fatalf("error should be preserved %+v", cloned)
+	}
+}
+
+func TestReturn(t *testing.T) {
+	if val, err := doDivide(4, 2).Return(); val != 2 || err != nil {
+		t.Fatalf("Return should return the Ok value, got %d %v", val, err)
+	}
+	if _, err := doDivide(1, 0).Return(); err == nil {
+		t.Fatal("Return should return the error")
+	}
+}
+
+func TestTee(t *testing.T) {
+	var dst int
+	if res := doDivide(4, 2).Tee(&dst); dst != 2 || res.Ok != 2 {
+		t.Fatalf("Ok value should be stored %d %+v", dst, res)
+	}
+	if res := doDivide(1, 0).Tee(&dst); dst != 2 || res.IsOk() {
+		t.Fatalf("dst should not be modified on error %d %+v", dst, res)
+	}
+}
+
+func TestGet(t *testing.T) {
+	if val, ok := doDivide(4, 2).Get(); val != 2 || !ok {
+		t.Fatalf("Get should return the Ok value, got %d %v", val, ok)
+	}
+	res := Result[int]{Ok: 1, Err: errors.New("error")}
+	if val, ok := res.Get(); val != 0 || ok {
+		t.Fatalf("Get should return the zero value, got %d %v", val, ok)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	if res := doDivide(4, 2).Recover(100); res.Ok != 2 || res.IsErr() {
+		t.Fatalf("Ok result should be unchanged %+v", res)
+	}
+	if res := doDivide(1, 0).Recover(100); res.Ok != 100 || res.IsErr() {
+		t.Fatalf("any error should be replaced %+v", res)
+	}
+	errOther := errors.New("other")
+	errWrapped := fmt.Errorf("wrapped: %w", errOther)
+	if res := (Result[int]{Err: errWrapped}).Recover(100, errOther); res.Ok != 100 || res.IsErr() {
+		t.Fatalf("matching error should be replaced %+v", res)
+	}
+	if res := doDivide(1, 0).Recover(100, errOther); res.Ok != 0 || res.IsOk() {
+		t.Fatalf("other errors should be kept %+v", res)
+	}
+}
+
+func TestTryConvert(t *testing.T) {
+	if res := TryConvert[string]("a"); res.Ok != "a" || res.IsErr() {
+		t.Fatalf("value should be converted %+v", res)
+	}
+	if res := TryConvert[string](1); res.IsOk() || res.Err.Error() != "cannot convert int to string" {
+		t.Fatalf("conversion should fail %+v", res)
+	}
+	if res := TryConvert[fmt.Stringer](nil); res.IsOk() || res.Err.Error() != "cannot convert nil to fmt.Stringer" {
+		t.Fatalf("nil should not be converted %+v", res)
+	}
+}
+
+func TestOkSliceErrSlice(t *testing.T) {
+	ok := doDivide(4, 2)
+	if s := ok.OkSlice(); len(s) != 1 || s[0] != 2 {
+		t.Fatalf("OkSlice should contain the Ok value %v", s)
+	}
+	if s := ok.ErrSlice(); s == nil || len(s) != 0 {
+		t.Fatalf("ErrSlice should be empty %v", s)
+	}
+	errored := doDivide(1, 0)
+	if s := errored.OkSlice(); s == nil || len(s) != 0 {
+		t.Fatalf("OkSlice should be empty %v", s)
+	}
+	if s := errored.ErrSlice(); len(s) != 1 || s[0] != errored.Err {
+		t.Fatalf("ErrSlice should contain the error %v", s)
+	}
+}
+
+func TestAsAnyCastResult(t *testing.T) {
+	results := []Result[any]{doDivide(4, 2).AsAny(), Result[string]{Ok: "a"}.AsAny(), doDivide(1, 0).AsAny()}
+	if res := CastResult[int](results[0]); res.Ok != 2 || res.IsErr() {
+		t.Fatalf("Ok value should be converted back %+v", res)
+	}
+	if res := CastResult[int](results[1]); res.IsOk() || res.Err.Error() != "cannot convert string to int" {
+		t.Fatalf("conversion to another type should fail %+v", res)
+	}
+	if res := CastResult[int](results[2]); res.Err != results[2].Err {
+		t.Fatalf("error should be kept %+v", res)
+	}
+	var p *int
+	if res := CastResult[*int](Result[*int]{Ok: p}.AsAny()); res.IsErr() || res.Ok != nil {
+		t.Fatalf("typed nil pointer should be converted back %+v", res)
+	}
+	if res := CastResult[error](Result[error]{}.AsAny()); res.IsErr() || res.Ok != nil {
+		t.Fatalf("nil interface should be converted back %+v", res)
+	}
+	if res := CastResult[any](FromFailable(nil).AsAny()); res.IsErr() || res.Ok != nil {
+		t.Fatalf("nil interface should be converted back %+v", res)
+	}
+	if res := CastResult[int](Result[any]{}); res.IsOk() {
+		t.Fatalf("nil should not be converted to a non-interface type %+v", res)
+	}
+}
+
+func TestFields(t *testing.T) {
+	if ok, err, isOk := doDivide(0, 2).Fields(); ok != 0 || err != nil || !isOk {
+		t.Fatalf("Fields should report an Ok zero value, got %d %v %v", ok, err, isOk)
+	}
+	if ok, err, isOk := doDivide(1, 0).Fields(); ok != 0 || err == nil || isOk {
+		t.Fatalf("Fields should report the error, got %d %v %v", ok, err, isOk)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ehtest provides helpers for testing code that returns eh Results.
+package ehtest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/olevski/eh"
+)
+
+// AssertOk fails the test if the result contains an error and otherwise
+// returns the Ok value.
+//
+// Example:
+//
+//	val := ehtest.AssertOk(t, doDivide(4, 2))
+func AssertOk[T any](t testing.TB, r eh.Result[T]) T {
+	t.Helper()
+	if r.IsErr() {
+		t.Fatalf("expected the result to be Ok, got error: %v", r.Err)
+	}
+	return r.Ok
+}
+
+// AssertErr fails the test if the result does not contain an error and
+// otherwise returns the error.
+func AssertErr[T any](t testing.TB, r eh.Result[T]) error {
+	t.Helper()
+	if r.IsOk() {
+		t.Fatalf("expected the result to contain an error, got Ok: %v", r.Ok)
+	}
+	return r.Err
+}
+
+// AssertErrIs fails the test if the error of the result does not match
+// target according to errors.Is.
+func AssertErrIs[T any](t testing.TB, r eh.Result[T], target error) {
+	t.Helper()
+	if !errors.Is(r.Err, target) {
+		t.Fatalf("expected the result error to match %v, got %v", target, r)
+	}
+}
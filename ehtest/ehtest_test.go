@@ -0,0 +1,71 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ehtest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/olevski/eh"
+)
+
+// recorder is a testing.TB that records failures instead of stopping the test.
+type recorder struct {
+	testing.TB
+	failed bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.failed = true
+}
+
+func TestAssertOk(t *testing.T) {
+	rec := &recorder{TB: t}
+	if val := AssertOk(rec, eh.Result[int]{Ok: 1}); val != 1 || rec.failed {
+		t.Fatalf("AssertOk should return 1 without failing, got %d", val)
+	}
+	AssertOk(rec, eh.Result[int]{Err: errors.New("error")})
+	if !rec.failed {
+		t.Fatal("AssertOk should fail for an errored result")
+	}
+}
+
+func TestAssertErr(t *testing.T) {
+	aErr := errors.New("error")
+	rec := &recorder{TB: t}
+	if err := AssertErr(rec, eh.Result[int]{Err: aErr}); err != aErr || rec.failed {
+		t.Fatalf("AssertErr should return the error without failing, got %v", err)
+	}
+	AssertErr(rec, eh.Result[int]{Ok: 1})
+	if !rec.failed {
+		t.Fatal("AssertErr should fail for an Ok result")
+	}
+}
+
+func TestAssertErrIs(t *testing.T) {
+	aErr := errors.New("error")
+	rec := &recorder{TB: t}
+	AssertErrIs(rec, eh.Result[int]{Err: fmt.Errorf("wrapped: %w", aErr)}, aErr)
+	if rec.failed {
+		t.Fatal("AssertErrIs should not fail for a matching error")
+	}
+	AssertErrIs(rec, eh.Result[int]{Err: errors.New("other")}, aErr)
+	if !rec.failed {
+		t.Fatal("AssertErrIs should fail for a different error")
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// ErrNull is the canonical error for a Result that represents an absent
+// value, such as a NULL column scanned from a database. It can be used to
+// tell a legitimately empty value apart from other failures, for example by
+// passing it to CatchError or Fallback.
+var ErrNull = errors.New("eh: no value")
+
+// ErrNilPointer is the error of the Result returned by Deref for an Ok nil pointer.
+var ErrNilPointer = errors.New("eh: nil pointer")
+
+var tagEscaped atomic.Bool
+
+// SetTagEscaped enables or disables tagging the errors that are recovered by
+// the escape hatches, so that IsEscaped can tell them apart from errors that
+// were set on a Result directly. A tagged error has the same message as the
+// original error, which remains available through errors.Is, errors.As and
+// errors.Unwrap, but it is no longer equal to it with ==. Tagging is off by
+// default.
+func SetTagEscaped(enabled bool) {
+	tagEscaped.Store(enabled)
+}
+
+var errorPrefix atomic.Pointer[string]
+
+// SetErrorPrefix sets a prefix that is added to every error escaping through
+// Eh(), for example the name of a component, so that an error escaping from
+// a function reads like `billing: open invoice.pdf: no such file or
+// directory`. The original error is still available through errors.Unwrap,
+// errors.Is and errors.As. An error is only prefixed the first time it
+// escapes, so nested escape hatch functions do not add the prefix again. An
+// empty prefix, the default, disables prefixing. It is safe to call
+// SetErrorPrefix concurrently with Eh(), but it is meant to be called once
+// during initialization.
+func SetErrorPrefix(prefix string) {
+	errorPrefix.Store(&prefix)
+}
+
+// prefixError annotates an escaped error with the prefix set by
+// SetErrorPrefix.
+type prefixError struct {
+	prefix string
+	err    error
+}
+
+func (e *prefixError) Error() string {
+	return e.prefix + ": " + e.err.Error()
+}
+
+func (e *prefixError) Unwrap() error {
+	return e.err
+}
+
+// withPrefix wraps err with the prefix set by SetErrorPrefix, unless there is
+// no prefix or err was already prefixed.
+func withPrefix(err error) error {
+	prefix := errorPrefix.Load()
+	if prefix == nil || *prefix == "" {
+		return err
+	}
+	var pe *prefixError
+	if errors.As(err, &pe) {
+		return err
+	}
+	return &prefixError{prefix: *prefix, err: err}
+}
+
+var onUnexpectedPanic atomic.Pointer[func(any)]
+
+// SetOnUnexpectedPanic sets a function that is called with the recovered
+// value when an escape hatch recovers a panic that was not raised by eh,
+// right before the panic is raised again. It can be used to log the type and
+// value of unexpected panics, the panic itself is not changed. A panic inside
+// f is recovered and ignored so that the original panic is still raised. If a
+// panic passes through several escape hatches f is called by each of them.
+// Passing nil removes the function, which is the default.
+func SetOnUnexpectedPanic(f func(recovered any)) {
+	if f == nil {
+		onUnexpectedPanic.Store(nil)
+		return
+	}
+	onUnexpectedPanic.Store(&f)
+}
+
+// notifyUnexpectedPanic calls the function set by SetOnUnexpectedPanic with
+// r, ignoring any panic raised by it.
+func notifyUnexpectedPanic(r any) {
+	f := onUnexpectedPanic.Load()
+	if f == nil {
+		return
+	}
+	defer func() {
+		_ = recover()
+	}()
+	(*f)(r)
+}
+
+// IsEscaped reports whether err escaped through Eh() and was recovered by an
+// escape hatch. This is only known for errors that were tagged, which is the
+// case when SetTagEscaped is enabled, when a stack trace was captured or when
+// a code was attached with EhCode.
+func IsEscaped(err error) bool {
+	var e *escapedError
+	return errors.As(err, &e)
+}
+
+// escapedError is stored in a Result by EscapeHatch instead of the original
+// error when extra information was captured while escaping.
+type escapedError struct {
+	err   error
+	stack []uintptr
+	code  string
+}
+
+func (e *escapedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *escapedError) Unwrap() error {
+	return e.err
+}
+
+// StackTrace returns the program counters of the stack at the point where
+// the error first escaped through Eh(), or nil if no stack was captured.
+func (e *escapedError) StackTrace() []uintptr {
+	if e.stack != nil {
+		return e.stack
+	}
+	var st interface{ StackTrace() []uintptr }
+	if errors.As(e.err, &st) {
+		return st.StackTrace()
+	}
+	return nil
+}
+
+// CodeOf returns the code that was attached to err when it escaped through
+// EhCode. If the error escaped multiple times with different codes, the
+// code attached last is returned.
+func CodeOf(err error) (string, bool) {
+	var e *escapedError
+	for errors.As(err, &e) {
+		if e.code != "" {
+			return e.code, true
+		}
+		err = e.err
+	}
+	return "", false
+}
+
+// MultiError is the error returned by the functions that aggregate several
+// errors, such as CollectAll, ZipAll, TryMapSlice and Race. Like the error
+// returned by errors.Join it implements Unwrap() []error, so errors.Is and
+// errors.As match any of the aggregated errors, while errors.As can also be
+// used to get the MultiError itself and inspect the individual errors.
+type MultiError struct {
+	errs []error
+}
+
+// Error returns the messages of the aggregated errors separated by newlines.
+func (e *MultiError) Error() string {
+	msgs := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the aggregated errors.
+func (e *MultiError) Unwrap() []error {
+	return e.errs
+}
+
+// Errors returns a copy of the aggregated errors in the order they were
+// recorded.
+func (e *MultiError) Errors() []error {
+	return slices.Clone(e.errs)
+}
+
+// joinErrors is like errors.Join but returns a *MultiError. Nil errors are
+// discarded and nil is returned if all the errors are nil.
+func joinErrors(errs ...error) error {
+	var nonNil []error
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+	if nonNil == nil {
+		return nil
+	}
+	return &MultiError{errs: nonNil}
+}
@@ -0,0 +1,188 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"errors"
+	"testing"
+)
+
+func doDivideCode(x int, y int, code string) (res Result[int]) {
+	defer EscapeHatch(&res)
+	return Result[int]{Ok: NewResult(divide(x, y)).EhCode(code)}
+}
+
+func TestEhCode(t *testing.T) {
+	res := doDivideCode(1, 0, "division")
+	if code, ok := CodeOf(res.Err); !ok || code != "division" {
+		t.Fatalf("code should be preserved, got %q %+v", code, res)
+	}
+	if res.Err.Error() != "divide by zero" {
+		t.Fatalf("error message should not change %+v", res)
+	}
+	if res := doDivideCode(4, 2, "division"); res.IsErr() || res.Ok != 2 {
+		t.Fatalf("result should be Ok %+v", res)
+	}
+}
+
+func TestCodeOfNested(t *testing.T) {
+	outer := func(code string) (res Result[int]) {
+		defer EscapeHatch(&res)
+		return Result[int]{Ok: doDivideCode(1, 0, "inner").EhCode(code)}
+	}
+	if code, _ := CodeOf(outer("").Err); code != "inner" {
+		t.Fatalf("inner code should be kept, got %q", code)
+	}
+	if code, _ := CodeOf(outer("outer").Err); code != "outer" {
+		t.Fatalf("outer code should take precedence, got %q", code)
+	}
+}
+
+func TestCodeOfNoCode(t *testing.T) {
+	if _, ok := CodeOf(doDivide(1, 0).Err); ok {
+		t.Fatal("error without a code should not report one")
+	}
+}
+
+func TestIsEscaped(t *testing.T) {
+	SetTagEscaped(true)
+	defer SetTagEscaped(false)
+	res := doDivideMultiple(4, 0)
+	if !IsEscaped(res.Err) || res.Err.Error() != "divide by zero" {
+		t.Fatalf("escaped error should be tagged %+v", res)
+	}
+	if IsEscaped(doDivide(4, 0).Err) {
+		t.Fatal("error set directly should not be tagged")
+	}
+	aErr := errors.New("error")
+	escaped := func() (r Result[int]) {
+		defer EscapeHatch(&r)
+		return Result[int]{Ok: FromFailableT[int](aErr).Eh()}
+	}
+	if res := escaped(); !errors.Is(res.Err, aErr) {
+		t.Fatalf("original error should still match %+v", res)
+	}
+}
+
+func TestIsEscapedDisabled(t *testing.T) {
+	if res := doDivideMultiple(4, 0); IsEscaped(res.Err) {
+		t.Fatalf("escaped error should not be tagged by default %+v", res)
+	}
+	if res := doDivideCode(1, 0, "division"); !IsEscaped(res.Err) {
+		t.Fatalf("escaped error with a code should be tagged %+v", res)
+	}
+}
+
+func TestEscapeHatchCode(t *testing.T) {
+	inner := func(code string) (res Result[int]) {
+		defer EscapeHatchCode(&res, "recoverable", "other")
+		return Result[int]{Ok: NewResult(divide(1, 0)).EhCode(code)}
+	}
+	outer := func(code string) (res Result[int]) {
+		defer EscapeHatch(&res)
+		res = inner(code)
+		res.Ok = 100
+		return res
+	}
+	if res := outer("recoverable"); res.Ok != 100 || res.IsOk() {
+		t.Fatalf("error should be recovered by the inner escape hatch %+v", res)
+	}
+	if res := outer("fatal"); res.Ok != 0 || res.IsOk() {
+		t.Fatalf("error should propagate to the outer escape hatch %+v", res)
+	}
+	if code, _ := CodeOf(outer("fatal").Err); code != "fatal" {
+		t.Fatalf("code should be preserved, got %q", code)
+	}
+}
+
+func TestEscapeHatchCodeNoCodes(t *testing.T) {
+	divideCode := func() (res Result[int]) {
+		defer EscapeHatchCode(&res)
+		return Result[int]{Ok: NewResult(divide(1, 0)).Eh()}
+	}
+	if res := divideCode(); res.IsOk() {
+		t.Fatalf("error should be recovered %+v", res)
+	}
+}
+
+func TestMultiError(t *testing.T) {
+	errA := errors.New("a")
+	errB := errors.New("b")
+	res := CollectAll([]Result[int]{{Err: errA}, {Ok: 1}, {Err: errB}})
+	var me *MultiError
+	if !errors.As(res.Err, &me) {
+		t.Fatalf("error should be a MultiError %+v", res)
+	}
+	if errs := me.Errors(); len(errs) != 2 || errs[0] != errA || errs[1] != errB {
+		t.Fatalf("errors should be in order %+v", errs)
+	}
+	if res.Err.Error() != "a\nb" {
+		t.Fatalf("messages should be separated by newlines %q", res.Err.Error())
+	}
+	if !errors.Is(res.Err, errB) {
+		t.Fatalf("errors.Is should match an aggregated error %+v", res)
+	}
+	me.Errors()[0] = nil
+	if me.Errors()[0] != errA {
+		t.Fatalf("Errors should return a copy %+v", me)
+	}
+	if err := joinErrors(nil, nil); err != nil {
+		t.Fatalf("joining nil errors should return nil %+v", err)
+	}
+}
+
+func TestSetErrorPrefix(t *testing.T) {
+	SetErrorPrefix("billing")
+	defer SetErrorPrefix("")
+	outer := func() (res Result[int]) {
+		defer EscapeHatch(&res)
+		return Result[int]{Ok: doDivideMultiple(4, 0).Eh()}
+	}
+	res := outer()
+	if res.Err.Error() != "billing: divide by zero" {
+		t.Fatalf("error should be prefixed once %+v", res)
+	}
+	if errors.Unwrap(res.Err).Error() != "divide by zero" {
+		t.Fatalf("original error should be unwrappable %+v", res)
+	}
+	SetErrorPrefix("")
+	if res := doDivideMultiple(4, 0); res.Err.Error() != "divide by zero" {
+		t.Fatalf("error should not be prefixed with an empty prefix %+v", res)
+	}
+}
+
+func TestSetOnUnexpectedPanic(t *testing.T) {
+	var seen []any
+	SetOnUnexpectedPanic(func(r any) {
+		seen = append(seen, r)
+		panic("hook")
+	})
+	defer SetOnUnexpectedPanic(nil)
+	crash := func() (res Result[int]) {
+		defer EscapeHatch(&res)
+		panic(42)
+	}
+	r := TryErr(func() { crash() })
+	if r == nil || r.Error() != "42" {
+		t.Fatalf("original panic should be raised again %v", r)
+	}
+	if len(seen) != 1 || seen[0] != 42 {
+		t.Fatalf("hook should be called with the panic value %v", seen)
+	}
+	seen = nil
+	if res := doDivideMultiple(4, 0); res.IsOk() || seen != nil {
+		t.Fatalf("hook should not be called for escaped errors %+v %v", res, seen)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+)
+
+var statusFromError atomic.Pointer[func(error) int]
+
+// SetStatusFromError sets the function used by WriteJSON to choose the
+// response status for an error, for example to map a not found error to 404.
+// If f is nil or returns 0 the status is 500, which is also the default.
+func SetStatusFromError(f func(error) int) {
+	if f == nil {
+		statusFromError.Store(nil)
+		return
+	}
+	statusFromError.Store(&f)
+}
+
+// errorStatus returns the response status for err.
+func errorStatus(err error) int {
+	if f := statusFromError.Load(); f != nil {
+		if status := (*f)(err); status != 0 {
+			return status
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// WriteJSON writes r to w as JSON. If there is no error the status is
+// okStatus and the body is the Ok value encoded as JSON. Otherwise the status
+// is chosen with the function set by SetStatusFromError and the body is
+// {"error":"<message>"}. An Ok value that cannot be encoded is written as an
+// error.
+//
+// Example:
+//
+//	func getUser(w http.ResponseWriter, req *http.Request) {
+//		eh.WriteJSON(w, findUser(req.PathValue("id")), http.StatusOK)
+//	}
+func WriteJSON[T any](w http.ResponseWriter, r Result[T], okStatus int) {
+	status := okStatus
+	var body []byte
+	err := r.Err
+	if err == nil {
+		body, err = json.Marshal(r.Ok)
+	}
+	if err != nil {
+		status = errorStatus(err)
+		body, _ = json.Marshal(struct {
+			Error string `json:"error"`
+		}{err.Error()})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// Handler adapts a function that computes a Result from a request into an
+// http.HandlerFunc. The Ok value is written to the response with onOk and the
+// error with onErr. If fn lets an error escape through Eh() without an
+// EscapeHatch the error is recovered and passed to onErr, and the response
+// status is 500 unless onErr sets one. Other panics are not
+// recovered and are handled by net/http as usual.
+//
+// Example:
+//
+//	mux.Handle("GET /users/{id}", eh.Handler(getUser, writeUser, writeError))
+func Handler[T any](fn func(*http.Request) Result[T], onOk func(http.ResponseWriter, T), onErr func(http.ResponseWriter, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		defer func() {
+			if r := recover(); r != nil {
+				sw := &defaultStatusWriter{ResponseWriter: w, status: http.StatusInternalServerError}
+				onErr(sw, recovered(r))
+				if !sw.wroteHeader {
+					sw.WriteHeader(sw.status)
+				}
+			}
+		}()
+		res := fn(req)
+		if res.Err != nil {
+			onErr(w, res.Err)
+			return
+		}
+		onOk(w, res.Ok)
+	}
+}
+
+// defaultStatusWriter is an http.ResponseWriter that writes status as the
+// response status when the body is written before WriteHeader is called.
+type defaultStatusWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (w *defaultStatusWriter) WriteHeader(status int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *defaultStatusWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(w.status)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the underlying http.ResponseWriter so that it can be used
+// with http.ResponseController.
+func (w *defaultStatusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,111 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func divideHandler(req *http.Request) Result[int] {
+	x := NewResult(strconv.Atoi(req.URL.Query().Get("x"))).Eh()
+	return doDivide(x, 2)
+}
+
+func TestHandler(t *testing.T) {
+	h := Handler(divideHandler, func(w http.ResponseWriter, v int) {
+		fmt.Fprint(w, v)
+	}, func(w http.ResponseWriter, err error) {
+		fmt.Fprint(w, err)
+	})
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest("GET", "/?x=4", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "2" {
+		t.Fatalf("Ok value should be written, got %d %q", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	h(rec, httptest.NewRequest("GET", "/?x=a", nil))
+	if rec.Code != http.StatusInternalServerError || rec.Body.String() != `strconv.Atoi: parsing "a": invalid syntax` {
+		t.Fatalf("escaped error should be written with a 500, got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandlerErrStatus(t *testing.T) {
+	h := Handler(func(*http.Request) Result[int] { return doDivide(1, 0) }, func(http.ResponseWriter, int) {
+		t.Fatal("onOk should not be called")
+	}, func(w http.ResponseWriter, err error) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest("GET", "/", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("onErr should set the status, got %d", rec.Code)
+	}
+}
+
+func TestHandlerEscapedNoWrite(t *testing.T) {
+	h := Handler(divideHandler, func(http.ResponseWriter, int) {}, func(http.ResponseWriter, error) {})
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest("GET", "/?x=a", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("escaped error should result in a 500, got %d", rec.Code)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteJSON(rec, Result[[]int]{Ok: []int{1, 2}}, http.StatusCreated)
+	if rec.Code != http.StatusCreated || rec.Body.String() != "[1,2]" {
+		t.Fatalf("Ok value should be written, got %d %q", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("content type should be set, got %q", ct)
+	}
+	rec = httptest.NewRecorder()
+	WriteJSON(rec, doDivide(1, 0), http.StatusOK)
+	if rec.Code != http.StatusInternalServerError || rec.Body.String() != `{"error":"divide by zero"}` {
+		t.Fatalf("error should be written with a 500, got %d %q", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	WriteJSON(rec, Result[func()]{Ok: func() {}}, http.StatusOK)
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("encoding error should be written with a 500, got %d", rec.Code)
+	}
+}
+
+func TestSetStatusFromError(t *testing.T) {
+	errNotFound := errors.New("not found")
+	SetStatusFromError(func(err error) int {
+		if errors.Is(err, errNotFound) {
+			return http.StatusNotFound
+		}
+		return 0
+	})
+	defer SetStatusFromError(nil)
+	rec := httptest.NewRecorder()
+	WriteJSON(rec, Result[int]{Err: fmt.Errorf("user: %w", errNotFound)}, http.StatusOK)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status should be derived from the error, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	WriteJSON(rec, doDivide(1, 0), http.StatusOK)
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status should default to 500, got %d", rec.Code)
+	}
+}
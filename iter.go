@@ -0,0 +1,109 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"bufio"
+	"io"
+	"iter"
+)
+
+// IterOk returns an iterator over the Ok values of the Results, skipping the
+// errored ones.
+//
+// Example:
+//
+//	for v := range eh.IterOk(results) {
+//		fmt.Println(v)
+//	}
+func IterOk[T any](rs []Result[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, r := range rs {
+			if r.IsOk() && !yield(r.Ok) {
+				return
+			}
+		}
+	}
+}
+
+// IterErr returns an iterator over the errors of the Results, skipping the
+// Ok ones.
+func IterErr[T any](rs []Result[T]) iter.Seq[error] {
+	return func(yield func(error) bool) {
+		for _, r := range rs {
+			if r.IsErr() && !yield(r.Err) {
+				return
+			}
+		}
+	}
+}
+
+// IterTry returns an iterator that yields the Ok values of the Results with a
+// nil error until it reaches an errored Result. The error of that Result is
+// yielded with the zero value of T and the iteration stops.
+//
+// Example:
+//
+//	for v, err := range eh.IterTry(results) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(v)
+//	}
+func IterTry[T any](rs []Result[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for _, r := range rs {
+			if r.IsErr() {
+				var zero T
+				yield(zero, r.Err)
+				return
+			}
+			if !yield(r.Ok, nil) {
+				return
+			}
+		}
+	}
+}
+
+// ReadLines returns an iterator that yields the lines of r as Ok Results,
+// without the line endings, using a bufio.Scanner. If reading fails, for
+// example because a line is longer than bufio.MaxScanTokenSize, an errored
+// Result with the error of the scanner is yielded last.
+//
+// Example:
+//
+//	func example(aFile string) (res eh.Result[[]string]) {
+//		defer eh.EscapeHatch(&res)
+//		file := eh.NewResult(os.Open(aFile)).Eh()
+//		defer file.Close()
+//		var lines []string
+//		for line := range eh.ReadLines(file) {
+//			lines = append(lines, line.Eh())
+//		}
+//		return eh.Result[[]string]{Ok: lines}
+//	}
+func ReadLines(r io.Reader) iter.Seq[Result[string]] {
+	return func(yield func(Result[string]) bool) {
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			if !yield(Result[string]{Ok: scanner.Text()}) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			yield(Result[string]{Err: err})
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"bufio"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestIterOk(t *testing.T) {
+	results := []Result[int]{doDivide(4, 2), doDivide(1, 0), doDivide(9, 3), doDivide(8, 2)}
+	var vals []int
+	for v := range IterOk(results) {
+		vals = append(vals, v)
+	}
+	if len(vals) != 3 || vals[0] != 2 || vals[1] != 3 || vals[2] != 4 {
+		t.Fatalf("all Ok values should be yielded in order %v", vals)
+	}
+	vals = nil
+	for v := range IterOk(results) {
+		vals = append(vals, v)
+		break
+	}
+	if len(vals) != 1 {
+		t.Fatalf("iteration should stop on break %v", vals)
+	}
+}
+
+func TestIterErr(t *testing.T) {
+	aErr := errors.New("error")
+	var errs []error
+	for err := range IterErr([]Result[int]{{Ok: 1}, {Err: aErr}, {Ok: 2}}) {
+		errs = append(errs, err)
+	}
+	if len(errs) != 1 || errs[0] != aErr {
+		t.Fatalf("only errors should be yielded %v", errs)
+	}
+}
+
+func TestIterTry(t *testing.T) {
+	results := []Result[int]{doDivide(4, 2), {Ok: 7, Err: errors.New("error")}, doDivide(9, 3)}
+	var vals []int
+	var errs []error
+	for v, err := range IterTry(results) {
+		vals = append(vals, v)
+		errs = append(errs, err)
+	}
+	if len(vals) != 2 || vals[0] != 2 || errs[0] != nil {
+		t.Fatalf("Ok values should be yielded with a nil error %v %v", vals, errs)
+	}
+	if vals[1] != 0 || errs[1] == nil || errs[1].Error() != "error" {
+		t.Fatalf("error should be yielded with the zero value %v %v", vals, errs)
+	}
+}
+
+func TestReadLines(t *testing.T) {
+	var lines []string
+	for res := range ReadLines(strings.NewReader("a\nb\r\n\nc")) {
+		lines = append(lines, res.MustUnwrap())
+	}
+	if strings.Join(lines, ",") != "a,b,,c" {
+		t.Fatalf("all lines should be yielded %q", lines)
+	}
+	long := "a\n" + strings.Repeat("x", bufio.MaxScanTokenSize+1)
+	var results []Result[string]
+	for res := range ReadLines(strings.NewReader(long)) {
+		results = append(results, res)
+	}
+	if len(results) != 2 || results[0].Ok != "a" || !errors.Is(results[1].Err, bufio.ErrTooLong) {
+		t.Fatalf("scanner error should be yielded last %+v", results)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+)
+
+// MarshalJSON encodes the result as `{"ok": <value>}` when there is no error
+// and as `{"err": "<error message>"}` otherwise.
+func (r Result[T]) MarshalJSON() ([]byte, error) {
+	if r.Err != nil {
+		return json.Marshal(struct {
+			Err string `json:"err"`
+		}{r.Err.Error()})
+	}
+	return json.Marshal(struct {
+		Ok T `json:"ok"`
+	}{r.Ok})
+}
+
+// UnmarshalJSON decodes a result encoded by MarshalJSON. Since the type of
+// the original error is lost, the error is restored with errors.New from the
+// error message. Unknown keys are rejected. Like for other types, decoding
+// the JSON null value leaves the result unchanged.
+func (r *Result[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil
+	}
+	var raw struct {
+		Ok  json.RawMessage `json:"ok"`
+		Err *string         `json:"err"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+	switch {
+	case raw.Ok != nil && raw.Err != nil:
+		return errors.New("eh: result JSON must not contain both ok and err")
+	case raw.Err != nil:
+		*r = Result[T]{Err: errors.New(*raw.Err)}
+	case raw.Ok != nil:
+		var ok T
+		if err := json.Unmarshal(raw.Ok, &ok); err != nil {
+			return err
+		}
+		*r = Result[T]{Ok: ok}
+	default:
+		return errors.New("eh: result JSON must contain either ok or err")
+	}
+	return nil
+}
+
+// DecodeStream returns an iterator that decodes a stream of JSON values, such
+// as newline delimited JSON, from r one at a time. Each value is yielded as
+// an Ok Result, or as an errored Result if it cannot be decoded into T, in
+// which case the iteration continues with the next value. The iteration ends
+// at the end of r, or after yielding the error if r cannot be read or does
+// not contain valid JSON, since the rest of the stream cannot be decoded.
+//
+// Example:
+//
+//	for res := range eh.DecodeStream[Record](resp.Body) {
+//		record := res.Eh()
+//		...
+//	}
+func DecodeStream[T any](r io.Reader) iter.Seq[Result[T]] {
+	return func(yield func(Result[T]) bool) {
+		dec := json.NewDecoder(r)
+		for {
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				if err != io.EOF {
+					yield(Result[T]{Err: err})
+				}
+				return
+			}
+			var v T
+			if err := json.Unmarshal(raw, &v); err != nil {
+				if !yield(Result[T]{Err: err}) {
+					return
+				}
+				continue
+			}
+			if !yield(Result[T]{Ok: v}) {
+				return
+			}
+		}
+	}
+}
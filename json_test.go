@@ -0,0 +1,113 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(doDivide(4, 2))
+	if err != nil || string(data) != `{"ok":2}` {
+		t.Fatalf("unexpected JSON %s %v", data, err)
+	}
+	data, err = json.Marshal(doDivide(1, 0))
+	if err != nil || string(data) != `{"err":"divide by zero"}` {
+		t.Fatalf("unexpected JSON %s %v", data, err)
+	}
+	data, err = json.Marshal(Result[string]{})
+	if err != nil || string(data) != `{"ok":""}` {
+		t.Fatalf("unexpected JSON %s %v", data, err)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	var res Result[[]int]
+	if err := json.Unmarshal([]byte(`{"ok":[1,2]}`), &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.IsErr() || len(res.Ok) != 2 {
+		t.Fatalf("result should be Ok %+v", res)
+	}
+	if err := json.Unmarshal([]byte(`{"err":"divide by zero"}`), &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.IsOk() || res.Err.Error() != "divide by zero" || res.Ok != nil {
+		t.Fatalf("result should contain the error %+v", res)
+	}
+}
+
+func TestUnmarshalJSONNull(t *testing.T) {
+	var data struct {
+		R Result[int] `json:"r"`
+	}
+	data.R = Result[int]{Ok: 1}
+	if err := json.Unmarshal([]byte(`{"r": null}`), &data); err != nil {
+		t.Fatal(err)
+	}
+	if data.R.IsErr() || data.R.Ok != 1 {
+		t.Fatalf("null should leave the result unchanged %+v", data.R)
+	}
+}
+
+func TestUnmarshalJSONInvalid(t *testing.T) {
+	for _, data := range []string{`{"ok":1,"other":2}`, `{"ok":1,"err":"error"}`, `{}`, `{"ok":"1"}`} {
+		var res Result[int]
+		if err := json.Unmarshal([]byte(data), &res); err == nil {
+			t.Fatalf("decoding %s should fail", data)
+		}
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	in := []Result[int]{{Ok: 1}, {Err: errors.New("error")}}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out []Result[int]
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out[0].Ok != 1 || out[1].Err.Error() != "error" {
+		t.Fatalf("results should survive the round trip %+v", out)
+	}
+}
+
+func TestDecodeStream(t *testing.T) {
+	var results []Result[int]
+	for res := range DecodeStream[int](strings.NewReader("1\n\"a\"\n3\n")) {
+		results = append(results, res)
+	}
+	if len(results) != 3 || results[0].Ok != 1 || results[1].IsOk() || results[2].Ok != 3 {
+		t.Fatalf("decoding should continue after a type error %+v", results)
+	}
+	results = nil
+	for res := range DecodeStream[int](strings.NewReader("1\n{x\n3\n")) {
+		results = append(results, res)
+	}
+	if len(results) != 2 || results[0].Ok != 1 || results[1].IsOk() {
+		t.Fatalf("decoding should stop after a syntax error %+v", results)
+	}
+	for res := range DecodeStream[int](strings.NewReader("1 2 3")) {
+		if res.Ok != 1 {
+			t.Fatalf("unexpected result %+v", res)
+		}
+		break
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"log/slog"
+)
+
+// LogValue implements the slog.LogValuer interface. The result is logged as
+// a group with an ok attribute holding the Ok value when there is no error
+// and an err attribute holding the error message otherwise.
+func (r Result[T]) LogValue() slog.Value {
+	if r.Err != nil {
+		return slog.GroupValue(slog.String("err", r.Err.Error()))
+	}
+	return slog.GroupValue(slog.Any("ok", r.Ok))
+}
+
+// ReturnLogged is like Return but if there is an error it is logged with
+// logger at the Error level before returning. Nothing is logged when there is
+// no error.
+func (r Result[T]) ReturnLogged(logger *slog.Logger) (T, error) {
+	if r.Err != nil {
+		logger.Error("eh: returning error", "err", r.Err)
+	}
+	return r.Ok, r.Err
+}
@@ -0,0 +1,55 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+var _ slog.LogValuer = Result[int]{}
+
+func TestLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("ok", "r", doDivide(4, 2))
+	logger.Info("err", "r", doDivide(1, 0))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("two lines should be logged, got %q", buf.String())
+	}
+	if !strings.Contains(lines[0], `"r":{"ok":2}`) {
+		t.Fatalf("Ok value should be logged, got %s", lines[0])
+	}
+	if !strings.Contains(lines[1], `"r":{"err":"divide by zero"}`) {
+		t.Fatalf("error should be logged, got %s", lines[1])
+	}
+}
+
+func TestReturnLogged(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	if val, err := doDivide(4, 2).ReturnLogged(logger); val != 2 || err != nil || buf.Len() != 0 {
+		t.Fatalf("nothing should be logged on success, got %q", buf.String())
+	}
+	if _, err := doDivide(1, 0).ReturnLogged(logger); err == nil {
+		t.Fatal("error should be returned")
+	}
+	if line := buf.String(); !strings.Contains(line, `"level":"ERROR"`) || !strings.Contains(line, `"err":"divide by zero"`) {
+		t.Fatalf("error should be logged at the Error level, got %s", line)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"errors"
+	"sync"
+)
+
+// errGoexit is the error of the Result of a call of f that did not return,
+// because it called runtime.Goexit, for example through t.FailNow.
+var errGoexit = errors.New("eh: f did not return")
+
+// Memoize returns a function that calls f the first time it is called and
+// returns the same Result on every call after that. Errors are cached as
+// well, use MemoizeRetryOnErr to call f again after an error. If f panics the
+// panic is raised again in the first caller and the later calls return the
+// panic as an errored Result, converted like in Try. If f exits its goroutine
+// with runtime.Goexit the later calls return an errored Result as well. The
+// returned function is safe for concurrent use, concurrent callers wait for
+// the first call of f to finish.
+func Memoize[T any](f func() Result[T]) func() Result[T] {
+	var once sync.Once
+	var res Result[T]
+	return func() Result[T] {
+		once.Do(func() {
+			defer func() {
+				if r := recover(); r != nil {
+					res = Result[T]{Err: panicError(r)}
+					panic(r)
+				}
+			}()
+			res = Result[T]{Err: errGoexit}
+			res = f()
+		})
+		return res
+	}
+}
+
+// MemoizeRetryOnErr is like Memoize but only Ok Results are cached. As long
+// as f returns errors it is called again on every call. The returned function
+// is safe for concurrent use, the calls of f never overlap.
+func MemoizeRetryOnErr[T any](f func() Result[T]) func() Result[T] {
+	var mu sync.Mutex
+	var res Result[T]
+	done := false
+	return func() Result[T] {
+		mu.Lock()
+		defer mu.Unlock()
+		if !done {
+			res = f()
+			done = res.IsOk()
+		}
+		return res
+	}
+}
+
+// Group deduplicates concurrent calls that produce a Result for the same
+// key, like golang.org/x/sync/singleflight. The zero value is ready to use.
+//
+// Example:
+//
+//	var users eh.Group[string, User]
+//
+//	func getUser(id string) eh.Result[User] {
+//		return users.Do(id, func() eh.Result[User] { return fetchUser(id) })
+//	}
+type Group[K comparable, T any] struct {
+	mu    sync.Mutex
+	calls map[K]*groupCall[T]
+}
+
+// groupCall is a call of Group.Do that is in progress or has finished.
+type groupCall[T any] struct {
+	wg  sync.WaitGroup
+	res Result[T]
+}
+
+// Do calls f and returns its Result, unless a call for the same key is
+// already in progress, in which case it waits for that call to finish and
+// returns the same Result. Once the call finishes the next call of Do for the
+// key calls f again. If f panics the panic is raised again in the goroutine
+// that called f and the waiting callers receive it as an errored Result. The
+// panic is raised again from within a deferred call, after the key has been
+// released but before the stack is unwound, so the traceback still shows
+// where f panicked. If f exits its goroutine with runtime.Goexit the waiting
+// callers receive an errored Result.
+func (g *Group[K, T]) Do(key K, f func() Result[T]) Result[T] {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[K]*groupCall[T])
+	}
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.res
+	}
+	c := &groupCall[T]{}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	defer func() {
+		if r := recover(); r != nil {
+			c.res = Result[T]{Err: panicError(r)}
+			defer panic(r)
+		}
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		c.wg.Done()
+	}()
+	c.res = Result[T]{Err: errGoexit}
+	c.res = f()
+	return c.res
+}
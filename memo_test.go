@@ -0,0 +1,221 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMemoize(t *testing.T) {
+	calls := 0
+	get := Memoize(func() Result[int] {
+		calls++
+		return Result[int]{Ok: calls}
+	})
+	wg := sync.WaitGroup{}
+	wg.Add(10)
+	for i := 0; i < 10; i++ {
+		go func() {
+			defer wg.Done()
+			if res := get(); res.Ok != 1 {
+				t.Errorf("cached result should be returned %+v", res)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls != 1 {
+		t.Fatalf("f should be called once, was called %d times", calls)
+	}
+}
+
+func TestMemoizeCachesErrors(t *testing.T) {
+	get := Memoize(failTimes(1))
+	if res := get(); res.IsOk() {
+		t.Fatalf("first call should fail %+v", res)
+	}
+	if res := get(); res.IsOk() {
+		t.Fatalf("error should be cached %+v", res)
+	}
+}
+
+func TestMemoizePanic(t *testing.T) {
+	calls := 0
+	get := Memoize(func() Result[int] {
+		calls++
+		panic("boom")
+	})
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Fatalf("panic should be raised again, got %v", r)
+			}
+		}()
+		get()
+	}()
+	if res := get(); res.IsOk() || res.Err.Error() != "boom" {
+		t.Fatalf("panic should be cached as an error %+v", res)
+	}
+	if calls != 1 {
+		t.Fatalf("f should be called once, was called %d times", calls)
+	}
+}
+
+func TestMemoizeGoexit(t *testing.T) {
+	get := Memoize(func() Result[int] {
+		runtime.Goexit()
+		return Result[int]{Ok: 1}
+	})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		get()
+	}()
+	<-done
+	if res := get(); res.IsOk() || res.Err != errGoexit {
+		t.Fatalf("call that did not return should be cached as an error %+v", res)
+	}
+}
+
+func TestMemoizeRetryOnErr(t *testing.T) {
+	get := MemoizeRetryOnErr(failTimes(2))
+	for i := 0; i < 2; i++ {
+		if res := get(); res.IsOk() {
+			t.Fatalf("call %d should fail %+v", i, res)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if res := get(); res.IsErr() || res.Ok != 3 {
+			t.Fatalf("Ok result should be cached %+v", res)
+		}
+	}
+}
+
+// waitForGroupCallers waits until n goroutines are blocked in Group.Do
+// waiting for a call in progress.
+func waitForGroupCallers(n int) {
+	buf := make([]byte, 1<<20)
+	for {
+		stacks := string(buf[:runtime.Stack(buf, true)])
+		waiting := 0
+		for _, stack := range strings.Split(stacks, "\n\n") {
+			if strings.Contains(stack, "sync.(*WaitGroup).Wait") && strings.Contains(stack, "eh.(*Group[...]).Do") {
+				waiting++
+			}
+		}
+		if waiting >= n {
+			return
+		}
+		runtime.Gosched()
+	}
+}
+
+func TestGroup(t *testing.T) {
+	var g Group[string, int]
+	var calls atomic.Int32
+	release := make(chan struct{})
+	started := make(chan struct{})
+	var once sync.Once
+	f := func() Result[int] {
+		calls.Add(1)
+		once.Do(func() { close(started) })
+		<-release
+		return Result[int]{Ok: 42}
+	}
+	results := make([]Result[int], 5)
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		results[0] = g.Do("key", f)
+	}()
+	<-started
+	wg.Add(4)
+	for i := 1; i < 5; i++ {
+		go func() {
+			defer wg.Done()
+			results[i] = g.Do("key", f)
+		}()
+	}
+	waitForGroupCallers(4)
+	close(release)
+	wg.Wait()
+	if calls.Load() != 1 {
+		t.Fatalf("f should be called once, was called %d times", calls.Load())
+	}
+	for _, res := range results {
+		if res.IsErr() || res.Ok != 42 {
+			t.Fatalf("all callers should receive the same result %+v", results)
+		}
+	}
+}
+
+func TestGroupGoexit(t *testing.T) {
+	var g Group[string, int]
+	release := make(chan struct{})
+	started := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		g.Do("key", func() Result[int] {
+			close(started)
+			<-release
+			runtime.Goexit()
+			return Result[int]{Ok: 1}
+		})
+	}()
+	<-started
+	waiting := make(chan Result[int])
+	go func() {
+		waiting <- g.Do("key", func() Result[int] { return Result[int]{Ok: 2} })
+	}()
+	waitForGroupCallers(1)
+	close(release)
+	<-done
+	if res := <-waiting; res.IsOk() || res.Err != errGoexit {
+		t.Fatalf("waiting caller should receive an error %+v", res)
+	}
+}
+
+func TestGroupSequential(t *testing.T) {
+	var g Group[string, int]
+	calls := 0
+	f := func() Result[int] {
+		calls++
+		return Result[int]{Ok: calls}
+	}
+	g.Do("key", f)
+	if res := g.Do("key", f); res.Ok != 2 {
+		t.Fatalf("finished calls should not be reused %+v", res)
+	}
+}
+
+func TestGroupPanic(t *testing.T) {
+	var g Group[string, int]
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Fatalf("panic should be raised again, got %v", r)
+			}
+		}()
+		g.Do("key", func() Result[int] { panic("boom") })
+	}()
+	if res := g.Do("key", func() Result[int] { return Result[int]{Ok: 1} }); res.Ok != 1 {
+		t.Fatalf("key should be released after a panic %+v", res)
+	}
+}
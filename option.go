@@ -0,0 +1,71 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+// Option represents a value that may or may not be present. Unlike Result,
+// which uses the Err field to signal failure, Option distinguishes "no value"
+// from a legitimate zero value with an explicit presence flag.
+type Option[T any] struct {
+	value T
+	some  bool
+}
+
+// Some creates an Option that contains the value v.
+func Some[T any](v T) Option[T] {
+	return Option[T]{value: v, some: true}
+}
+
+// None creates an Option that does not contain a value.
+func None[T any]() Option[T] {
+	return Option[T]{}
+}
+
+// IsSome returns true when the option contains a value and otherwise false
+func (o Option[T]) IsSome() bool {
+	return o.some
+}
+
+// IsNone returns true when the option does not contain a value and otherwise false
+func (o Option[T]) IsNone() bool {
+	return !o.some
+}
+
+// MustUnwrap returns the contained value or panics if there is no value.
+func (o Option[T]) MustUnwrap() T {
+	if !o.some {
+		panic("expected the option to contain a value")
+	}
+	return o.value
+}
+
+// OkOr converts the option into a Result. The contained value becomes the Ok
+// value when there is one, otherwise the Result contains err.
+func (o Option[T]) OkOr(err error) Result[T] {
+	if !o.some {
+		return Result[T]{Err: err}
+	}
+	return Result[T]{Ok: o.value}
+}
+
+// ToOption converts the result into an Option that contains the Ok value
+// when there is no error and no value otherwise. The error is discarded.
+// This is the equivalent of Rust's Result::ok, the name Ok is already taken
+// by the field of the Result struct.
+func (r Result[T]) ToOption() Option[T] {
+	if r.IsErr() {
+		return None[T]()
+	}
+	return Some(r.Ok)
+}
@@ -0,0 +1,67 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSome(t *testing.T) {
+	opt := Some(0)
+	if !opt.IsSome() || opt.IsNone() {
+		t.Fatalf("option should contain a value %+v", opt)
+	}
+	if opt.MustUnwrap() != 0 {
+		t.Fatal("MustUnwrap should return 0")
+	}
+}
+
+func TestNone(t *testing.T) {
+	opt := None[int]()
+	if opt.IsSome() || !opt.IsNone() {
+		t.Fatalf("option should not contain a value %+v", opt)
+	}
+}
+
+func TestOptionMustUnwrapPanic(t *testing.T) {
+	opt := None[int]()
+	defer func() { recover() }()
+	_ = opt.MustUnwrap()
+	t.Fatal("code should have panicked")
+}
+
+func TestOkOr(t *testing.T) {
+	aErr := errors.New("error")
+	res := Some(1).OkOr(aErr)
+	if res.IsErr() || res.Ok != 1 {
+		t.Fatalf("result should be Ok %+v", res)
+	}
+	res = None[int]().OkOr(aErr)
+	if res.Err != aErr {
+		t.Fatalf("result should contain the supplied error %+v", res)
+	}
+}
+
+func TestToOption(t *testing.T) {
+	opt := doDivide(4, 2).ToOption()
+	if !opt.IsSome() || opt.MustUnwrap() != 2 {
+		t.Fatalf("option should contain 2 %+v", opt)
+	}
+	opt = doDivide(1, 0).ToOption()
+	if !opt.IsNone() {
+		t.Fatalf("option should be empty %+v", opt)
+	}
+}
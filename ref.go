@@ -0,0 +1,86 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+// ResultRef is like Result but it is meant to be used through a pointer so
+// that a large Ok value is not copied when the result is passed around. Note
+// that this only matters for large value types such as arrays or big structs,
+// slices, maps and pointers are cheap to copy and can be used with Result
+// directly. Allocating a new ResultRef on every call usually costs more than
+// copying the Result, so ResultRef pays off when the same result is passed
+// down many calls or reused, see BenchmarkLargePayload.
+//
+// Example:
+//
+//	func example(aFile string) (res *eh.ResultRef[[4096]byte]) {
+//		defer eh.EscapeHatchRef(&res)
+//		res = &eh.ResultRef[[4096]byte]{}
+//		file := eh.NewResult(os.Open(aFile)).Eh()
+//		_ = eh.NewResult(file.Read(res.Ok[:])).Eh()
+//		return res
+//	}
+type ResultRef[T any] struct {
+	Ok  T
+	Err error
+}
+
+// NewResultRef creates a ResultRef from any value and an error.
+func NewResultRef[T any](val T, err error) *ResultRef[T] {
+	return &ResultRef[T]{val, err}
+}
+
+// Eh checks if there is an error in the result and if so then it will
+// panic with the error that was encountered. If there is no error the Ok value is returned.
+func (r *ResultRef[T]) Eh() T {
+	if r.Err != nil {
+		escape(r.Err, "")
+	}
+	return r.Ok
+}
+
+// IsOk returns true when result has no error and otherwise false
+func (r *ResultRef[T]) IsOk() bool {
+	return r.Err == nil
+}
+
+// IsErr returns true when result has error and otherwise false
+func (r *ResultRef[T]) IsErr() bool {
+	return r.Err != nil
+}
+
+// Unwrap method returns a value and an error
+func (r *ResultRef[T]) Unwrap() (T, error) {
+	return r.Ok, r.Err
+}
+
+// Result returns a copy of the ResultRef as a Result.
+func (r *ResultRef[T]) Result() Result[T] {
+	return Result[T]{r.Ok, r.Err}
+}
+
+// EscapeHatchRef is the same as EscapeHatch but it populates a ResultRef. If
+// the pointer pointed to by res is nil, a new ResultRef is allocated,
+// otherwise the referenced ResultRef is updated in place and its Ok value is
+// reset to the zero value.
+func EscapeHatchRef[T any](res **ResultRef[T]) {
+	if r := recover(); r != nil {
+		err := recovered(r)
+		if *res == nil {
+			*res = &ResultRef[T]{Err: err}
+			return
+		}
+		**res = ResultRef[T]{Err: err}
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"testing"
+)
+
+func doDivideRef(x int, y int) (res *ResultRef[int]) {
+	defer EscapeHatchRef(&res)
+	res = &ResultRef[int]{Ok: 100}
+	res.Ok = NewResultRef(divide(x, y)).Eh()
+	return res
+}
+
+func TestResultRef(t *testing.T) {
+	res := doDivideRef(4, 2)
+	if res.IsErr() || res.Ok != 2 {
+		t.Fatalf("result should be Ok %+v", res)
+	}
+	res = doDivideRef(1, 0)
+	if res.IsOk() || res.Ok != 0 {
+		t.Fatalf("referenced result should be updated %+v", res)
+	}
+	if val, err := res.Unwrap(); val != 0 || err.Error() != "divide by zero" {
+		t.Fatalf("Unwrap should return the error, got %d %v", val, err)
+	}
+}
+
+func TestEscapeHatchRefNil(t *testing.T) {
+	divideRef := func() (res *ResultRef[int]) {
+		defer EscapeHatchRef(&res)
+		return &ResultRef[int]{Ok: NewResult(divide(1, 0)).Eh()}
+	}
+	if res := divideRef(); res == nil || res.IsOk() {
+		t.Fatalf("a new result should be allocated %+v", res)
+	}
+}
+
+type largePayload [4096]byte
+
+func largeResult(i int) (res Result[largePayload]) {
+	defer EscapeHatch(&res)
+	res.Ok[0] = byte(i)
+	return res
+}
+
+func largeResultRef(i int) (res *ResultRef[largePayload]) {
+	defer EscapeHatchRef(&res)
+	res = &ResultRef[largePayload]{}
+	res.Ok[0] = byte(i)
+	return res
+}
+
+//go:noinline
+func passResult(res Result[largePayload], depth int) byte {
+	if depth == 0 {
+		return res.Ok[0]
+	}
+	return passResult(res, depth-1)
+}
+
+//go:noinline
+func passResultRef(res *ResultRef[largePayload], depth int) byte {
+	if depth == 0 {
+		return res.Ok[0]
+	}
+	return passResultRef(res, depth-1)
+}
+
+// BenchmarkLargePayload compares returning a large Ok value through Result
+// and through a ResultRef, and passing it down a chain of function calls.
+func BenchmarkLargePayload(b *testing.B) {
+	b.Run("Return/Result", func(b *testing.B) {
+		sum := 0
+		for i := 0; i < b.N; i++ {
+			res := largeResult(i)
+			sum += int(res.Ok[0])
+		}
+		_ = sum
+	})
+	b.Run("Return/ResultRef", func(b *testing.B) {
+		sum := 0
+		for i := 0; i < b.N; i++ {
+			res := largeResultRef(i)
+			sum += int(res.Ok[0])
+		}
+		_ = sum
+	})
+	b.Run("Pass/Result", func(b *testing.B) {
+		res := largeResult(1)
+		for i := 0; i < b.N; i++ {
+			_ = passResult(res, 8)
+		}
+	})
+	b.Run("Pass/ResultRef", func(b *testing.B) {
+		res := largeResultRef(1)
+		for i := 0; i < b.N; i++ {
+			_ = passResultRef(res, 8)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+// Result2 is like Result but for functions that return two values and an error.
+type Result2[A, B any] struct {
+	Ok1 A
+	Ok2 B
+	Err error
+}
+
+// NewResult2 creates a Result2 from two values and an error. You can use this to
+// convert the output of any function that returns two values and an error.
+func NewResult2[A, B any](a A, b B, err error) Result2[A, B] {
+	return Result2[A, B]{a, b, err}
+}
+
+// Eh checks if there is an error in the result and if so then it will
+// panic with the error that was encountered. If there is no error the Ok values are returned.
+// The error can be recovered by EscapeHatch or EscapeHatch2 just like for a Result.
+func (r Result2[A, B]) Eh() (A, B) {
+	if r.Err != nil {
+		escape(r.Err, "")
+	}
+	return r.Ok1, r.Ok2
+}
+
+// IsOk returns true when result has no error and otherwise false
+func (r Result2[A, B]) IsOk() bool {
+	return r.Err == nil
+}
+
+// IsErr returns true when result has error and otherwise false
+func (r Result2[A, B]) IsErr() bool {
+	return r.Err != nil
+}
+
+// Unwrap method returns both values and an error
+func (r Result2[A, B]) Unwrap() (A, B, error) {
+	return r.Ok1, r.Ok2, r.Err
+}
+
+// EscapeHatch2 is the same as EscapeHatch but it populates a Result2.
+//
+// Example:
+//
+//	func example(addr string) (res eh.Result2[string, int]) {
+//		defer eh.EscapeHatch2(&res)
+//		host, port := eh.NewResult2(net.SplitHostPort(addr)).Eh()
+//		portNum := eh.NewResult(strconv.Atoi(port)).Eh()
+//		return eh.Result2[string, int]{Ok1: host, Ok2: portNum}
+//	}
+func EscapeHatch2[A, B any](res *Result2[A, B]) {
+	if r := recover(); r != nil {
+		*res = Result2[A, B]{Err: recovered(r)}
+	}
+}
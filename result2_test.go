@@ -0,0 +1,55 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"fmt"
+	"testing"
+)
+
+func divmod(x int, y int) (int, int, error) {
+	if y == 0 {
+		return 0, 0, fmt.Errorf("divide by zero")
+	}
+	return x / y, x % y, nil
+}
+
+func doDivmod(x int, y int) (res Result2[int, int]) {
+	defer EscapeHatch2(&res)
+	q, r := NewResult2(divmod(x, y)).Eh()
+	return Result2[int, int]{Ok1: q, Ok2: r}
+}
+
+func TestResult2(t *testing.T) {
+	res := doDivmod(5, 2)
+	if res.IsErr() || res.Ok1 != 2 || res.Ok2 != 1 {
+		t.Fatalf("Result2 should be Ok with 2 and 1 %+v", res)
+	}
+	res = doDivmod(5, 0)
+	if res.IsOk() || res.Ok1 != 0 || res.Ok2 != 0 {
+		t.Fatalf("Result2 should contain an error %+v", res)
+	}
+}
+
+func TestResult2WithEscapeHatch(t *testing.T) {
+	doDivmodSum := func(x int, y int) (res Result[int]) {
+		defer EscapeHatch(&res)
+		q, r := NewResult2(divmod(x, y)).Eh()
+		return Result[int]{Ok: q + r}
+	}
+	if res := doDivmodSum(5, 0); res.IsOk() {
+		t.Fatalf("Result should contain an error %+v", res)
+	}
+}
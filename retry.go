@@ -0,0 +1,85 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+)
+
+// ErrNoAttempts is returned by the retry helpers when they are asked to make
+// less than one attempt.
+var ErrNoAttempts = errors.New("eh: the number of attempts must be positive")
+
+// Retry calls f up to attempts times and returns the first Ok Result. If all
+// the attempts fail the Result of the last attempt is returned. If attempts
+// is not positive f is never called and the Result contains ErrNoAttempts.
+func Retry[T any](attempts int, f func() Result[T]) Result[T] {
+	return RetryBackoff(attempts, 0, f)
+}
+
+// RetryBackoff is like Retry but it sleeps between the attempts. The first
+// sleep lasts base and every following sleep is twice as long as the previous.
+// There is no sleep before the first attempt or after the last one.
+func RetryBackoff[T any](attempts int, base time.Duration, f func() Result[T]) Result[T] {
+	if attempts <= 0 {
+		return Result[T]{Err: ErrNoAttempts}
+	}
+	res := f()
+	for i := 1; i < attempts && res.IsErr(); i++ {
+		time.Sleep(backoff(base, i))
+		res = f()
+	}
+	return res
+}
+
+// RetryContext is like RetryBackoff but it stops as soon as ctx is done, in
+// which case the returned Result contains the context error. The sleeps
+// between the attempts are interrupted when the context is done. If f
+// succeeds its Result is returned immediately.
+func RetryContext[T any](ctx context.Context, attempts int, base time.Duration, f func() Result[T]) Result[T] {
+	if attempts <= 0 {
+		return Result[T]{Err: ErrNoAttempts}
+	}
+	for i := 0; ; i++ {
+		if err := ctx.Err(); err != nil {
+			return Result[T]{Err: err}
+		}
+		res := f()
+		if res.IsOk() || i+1 == attempts {
+			return res
+		}
+		timer := time.NewTimer(backoff(base, i+1))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return Result[T]{Err: ctx.Err()}
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns how long to wait before the given attempt, with attempt 1
+// being the first retry. The delay saturates at the maximum time.Duration
+// instead of overflowing.
+func backoff(base time.Duration, attempt int) time.Duration {
+	shift := attempt - 1
+	if base > 0 && (shift >= 63 || base > math.MaxInt64>>shift) {
+		return math.MaxInt64
+	}
+	return base << shift
+}
@@ -0,0 +1,131 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func failTimes(n int) func() Result[int] {
+	calls := 0
+	return func() Result[int] {
+		calls++
+		if calls <= n {
+			return Result[int]{Err: fmt.Errorf("attempt %d failed", calls)}
+		}
+		return Result[int]{Ok: calls}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	res := Retry(3, failTimes(2))
+	if res.IsErr() || res.Ok != 3 {
+		t.Fatalf("third attempt should succeed %+v", res)
+	}
+}
+
+func TestRetryAllFail(t *testing.T) {
+	res := Retry(3, failTimes(5))
+	if res.IsOk() || res.Err.Error() != "attempt 3 failed" {
+		t.Fatalf("error of the last attempt should be returned %+v", res)
+	}
+}
+
+func TestRetryNoAttempts(t *testing.T) {
+	res := Retry(0, func() Result[int] {
+		t.Fatal("f should not be called")
+		return Result[int]{}
+	})
+	if !errors.Is(res.Err, ErrNoAttempts) {
+		t.Fatalf("result should contain ErrNoAttempts %+v", res)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	start := time.Now()
+	res := RetryBackoff(3, 10*time.Millisecond, failTimes(2))
+	if res.IsErr() || res.Ok != 3 {
+		t.Fatalf("third attempt should succeed %+v", res)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Fatalf("should have slept at least 30ms, slept %v", elapsed)
+	}
+}
+
+func TestRetryBackoffFirstAttempt(t *testing.T) {
+	start := time.Now()
+	res := RetryBackoff(3, time.Hour, failTimes(0))
+	if res.IsErr() || time.Since(start) > time.Second {
+		t.Fatalf("successful first attempt should not sleep %+v", res)
+	}
+}
+
+func TestBackoffSaturates(t *testing.T) {
+	if d := backoff(time.Second, 3); d != 4*time.Second {
+		t.Fatalf("delay should double with every attempt, got %v", d)
+	}
+	prev := time.Duration(0)
+	for attempt := 1; attempt <= 200; attempt++ {
+		d := backoff(time.Second, attempt)
+		if d < prev {
+			t.Fatalf("delay should never decrease, got %v after %v at attempt %d", d, prev, attempt)
+		}
+		prev = d
+	}
+	if d := backoff(time.Second, 64); d != math.MaxInt64 {
+		t.Fatalf("delay should saturate, got %v", d)
+	}
+}
+
+func TestRetryContext(t *testing.T) {
+	res := RetryContext(context.Background(), 3, time.Millisecond, failTimes(2))
+	if res.IsErr() || res.Ok != 3 {
+		t.Fatalf("third attempt should succeed %+v", res)
+	}
+	res = RetryContext(context.Background(), 2, time.Millisecond, failTimes(2))
+	if res.IsOk() || res.Err.Error() != "attempt 2 failed" {
+		t.Fatalf("error of the last attempt should be returned %+v", res)
+	}
+}
+
+func TestRetryContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	res := RetryContext(ctx, 3, time.Hour, failTimes(5))
+	if !errors.Is(res.Err, context.DeadlineExceeded) {
+		t.Fatalf("result should contain the context error %+v", res)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatal("sleep should have been interrupted by the context")
+	}
+}
+
+func TestRetryContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	res := RetryContext(ctx, 3, time.Millisecond, func() Result[int] {
+		t.Fatal("f should not be called")
+		return Result[int]{}
+	})
+	if !errors.Is(res.Err, context.Canceled) {
+		t.Fatalf("result should contain the context error %+v", res)
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Collect turns a slice of Results into a Result of a slice. If every Result
+// is Ok the values are returned in the same order, otherwise the error of the
+// first errored Result is returned.
+func Collect[T any](rs []Result[T]) Result[[]T] {
+	vals := make([]T, 0, len(rs))
+	for _, r := range rs {
+		if r.IsErr() {
+			return Result[[]T]{Err: r.Err}
+		}
+		vals = append(vals, r.Ok)
+	}
+	return Result[[]T]{Ok: vals}
+}
+
+// CollectAll is like Collect but instead of stopping at the first error it
+// joins the errors of all errored Results in a *MultiError. The individual
+// errors can still be matched with errors.Is and errors.As.
+func CollectAll[T any](rs []Result[T]) Result[[]T] {
+	vals := make([]T, 0, len(rs))
+	var errs []error
+	for _, r := range rs {
+		if r.IsErr() {
+			errs = append(errs, r.Err)
+			continue
+		}
+		vals = append(vals, r.Ok)
+	}
+	if errs != nil {
+		return Result[[]T]{Err: joinErrors(errs...)}
+	}
+	return Result[[]T]{Ok: vals}
+}
+
+// SortOkFirst reorders rs in place so that the Ok Results come before the
+// errored ones. The relative order within both groups is kept.
+func SortOkFirst[T any](rs []Result[T]) {
+	sort.SliceStable(rs, func(i, j int) bool {
+		return rs[i].IsOk() && rs[j].IsErr()
+	})
+}
+
+// SortErrFirst reorders rs in place so that the errored Results come before
+// the Ok ones. The relative order within both groups is kept.
+func SortErrFirst[T any](rs []Result[T]) {
+	sort.SliceStable(rs, func(i, j int) bool {
+		return rs[i].IsErr() && rs[j].IsOk()
+	})
+}
+
+// Partition splits a slice of Results into the Ok values and the errors,
+// keeping the input order in both. Neither of the returned slices is nil.
+func Partition[T any](rs []Result[T]) ([]T, []error) {
+	vals := []T{}
+	errs := []error{}
+	for _, r := range rs {
+		if r.IsErr() {
+			errs = append(errs, r.Err)
+			continue
+		}
+		vals = append(vals, r.Ok)
+	}
+	return vals, errs
+}
+
+// Fold combines the items from left to right into an accumulator, starting
+// with init. It stops at the first error returned by f and returns it,
+// otherwise it returns the final accumulator.
+//
+// Example:
+//
+//	sum := eh.Fold(lines, 0, func(acc int, line string) eh.Result[int] {
+//		n := eh.NewResult(strconv.Atoi(line))
+//		return eh.Result[int]{Ok: acc + n.Ok, Err: n.Err}
+//	})
+func Fold[T, Acc any](items []T, init Acc, f func(Acc, T) Result[Acc]) Result[Acc] {
+	acc := init
+	for _, item := range items {
+		res := f(acc, item)
+		if res.IsErr() {
+			return res
+		}
+		acc = res.Ok
+	}
+	return Result[Acc]{Ok: acc}
+}
+
+// MapSlice calls f for each of the items and returns the Ok values in the
+// same order. It stops at the first error, which is returned wrapped with
+// the index of the item that failed, like `index 2: <error>`.
+func MapSlice[T, U any](items []T, f func(T) Result[U]) Result[[]U] {
+	vals := make([]U, 0, len(items))
+	for i, item := range items {
+		res := f(item)
+		if res.IsErr() {
+			return Result[[]U]{Err: fmt.Errorf("index %d: %w", i, res.Err)}
+		}
+		vals = append(vals, res.Ok)
+	}
+	return Result[[]U]{Ok: vals}
+}
+
+// TryMapSlice is like MapSlice but it does not stop at the first error. The
+// Ok values are returned in the same order as the items, skipping the items
+// that failed. The errors of all the items that failed are wrapped with their
+// index and joined in a *MultiError, the returned error is nil when all the
+// items succeeded.
+func TryMapSlice[T, U any](items []T, f func(T) Result[U]) ([]U, error) {
+	vals := make([]U, 0, len(items))
+	var errs []error
+	for i, item := range items {
+		res := f(item)
+		if res.IsErr() {
+			errs = append(errs, fmt.Errorf("index %d: %w", i, res.Err))
+			continue
+		}
+		vals = append(vals, res.Ok)
+	}
+	return vals, joinErrors(errs...)
+}
+
+// NewResults calls each of the functions once and returns their outputs as
+// Results in the same order. Panics inside the functions are not recovered.
+//
+// Example:
+//
+//	configs := eh.Collect(eh.NewResults(loadDefaults, loadUserConfig, loadEnv)).Eh()
+func NewResults[T any](fs ...func() (T, error)) []Result[T] {
+	results := make([]Result[T], 0, len(fs))
+	for _, f := range fs {
+		results = append(results, NewResult(f()))
+	}
+	return results
+}
+
+// Number is a constraint that permits any integer or floating point type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Sum adds up the Ok values of the Results. It stops at the first error and
+// returns it. The sum of no Results is 0.
+func Sum[T Number](rs []Result[T]) Result[T] {
+	var sum T
+	for _, r := range rs {
+		if r.IsErr() {
+			return Result[T]{Err: r.Err}
+		}
+		sum += r.Ok
+	}
+	return Result[T]{Ok: sum}
+}
+
+// EhAll is like calling Eh on every Result. It panics with the error of the
+// first errored Result so that it can be recovered by EscapeHatch, otherwise
+// it returns the Ok values in the same order. It is the counterpart of
+// Collect for escape hatch functions.
+//
+// Example:
+//
+//	func example(files []string) (res eh.Result[int]) {
+//		defer eh.EscapeHatch(&res)
+//		sizes := eh.EhAll(eh.ParallelMap(files, fileSize))
+//		...
+//	}
+func EhAll[T any](rs []Result[T]) []T {
+	vals := make([]T, 0, len(rs))
+	for _, r := range rs {
+		if r.IsErr() {
+			escape(r.Err, "")
+		}
+		vals = append(vals, r.Ok)
+	}
+	return vals
+}
@@ -0,0 +1,200 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestCollect(t *testing.T) {
+	res := Collect([]Result[int]{doDivide(4, 2), doDivide(9, 3), doDivide(1, 1)})
+	if res.IsErr() || len(res.Ok) != 3 || res.Ok[0] != 2 || res.Ok[1] != 3 || res.Ok[2] != 1 {
+		t.Fatalf("Collect should return all values in order %+v", res)
+	}
+}
+
+func TestCollectFirstError(t *testing.T) {
+	firstErr := errors.New("first")
+	res := Collect([]Result[int]{{Ok: 1}, {Err: firstErr}, {Err: errors.New("second")}})
+	if res.Err != firstErr || res.Ok != nil {
+		t.Fatalf("Collect should return the first error %+v", res)
+	}
+}
+
+func TestCollectEmpty(t *testing.T) {
+	res := Collect([]Result[int]{})
+	if res.IsErr() || res.Ok == nil || len(res.Ok) != 0 {
+		t.Fatalf("Collect should return an empty slice %+v", res)
+	}
+}
+
+func TestCollectAll(t *testing.T) {
+	res := CollectAll([]Result[int]{doDivide(4, 2), doDivide(9, 3)})
+	if res.IsErr() || len(res.Ok) != 2 || res.Ok[0] != 2 || res.Ok[1] != 3 {
+		t.Fatalf("CollectAll should return all values in order %+v", res)
+	}
+}
+
+func TestCollectAllErrors(t *testing.T) {
+	firstErr := errors.New("first")
+	secondErr := errors.New("second")
+	res := CollectAll([]Result[int]{{Err: firstErr}, {Ok: 1}, {Err: secondErr}})
+	if res.Ok != nil || !errors.Is(res.Err, firstErr) || !errors.Is(res.Err, secondErr) {
+		t.Fatalf("CollectAll should join all errors %+v", res)
+	}
+}
+
+func TestSortOkFirst(t *testing.T) {
+	errA := errors.New("a")
+	errB := errors.New("b")
+	rs := []Result[int]{{Err: errA}, {Ok: 1}, {Err: errB}, {Ok: 2}}
+	SortOkFirst(rs)
+	if rs[0].Ok != 1 || rs[1].Ok != 2 || rs[2].Err != errA || rs[3].Err != errB {
+		t.Fatalf("Ok results should come first in the same order %+v", rs)
+	}
+	SortErrFirst(rs)
+	if rs[0].Err != errA || rs[1].Err != errB || rs[2].Ok != 1 || rs[3].Ok != 2 {
+		t.Fatalf("errored results should come first in the same order %+v", rs)
+	}
+	SortOkFirst[int](nil)
+	SortErrFirst([]Result[int]{})
+}
+
+func TestPartition(t *testing.T) {
+	firstErr := errors.New("first")
+	secondErr := errors.New("second")
+	vals, errs := Partition([]Result[int]{{Ok: 1}, {Err: firstErr}, {Ok: 2}, {Err: secondErr}})
+	if len(vals) != 2 || vals[0] != 1 || vals[1] != 2 {
+		t.Fatalf("Partition should return the values in order %v", vals)
+	}
+	if len(errs) != 2 || errs[0] != firstErr || errs[1] != secondErr {
+		t.Fatalf("Partition should return the errors in order %v", errs)
+	}
+}
+
+func TestPartitionEmpty(t *testing.T) {
+	vals, errs := Partition[int](nil)
+	if vals == nil || errs == nil || len(vals) != 0 || len(errs) != 0 {
+		t.Fatalf("Partition should return empty slices %v %v", vals, errs)
+	}
+}
+
+func TestFold(t *testing.T) {
+	sumQuotients := func(acc int, y int) Result[int] {
+		res := doDivide(12, y)
+		return Result[int]{Ok: acc + res.Ok, Err: res.Err}
+	}
+	if res := Fold([]int{1, 2, 3}, 1, sumQuotients); res.IsErr() || res.Ok != 23 {
+		t.Fatalf("Fold should return 23 %+v", res)
+	}
+	calls := 0
+	res := Fold([]int{1, 0, 3}, 0, func(acc int, y int) Result[int] {
+		calls++
+		return sumQuotients(acc, y)
+	})
+	if res.IsOk() || calls != 2 {
+		t.Fatalf("Fold should stop at the first error %+v", res)
+	}
+	if res := Fold(nil, 5, sumQuotients); res.IsErr() || res.Ok != 5 {
+		t.Fatalf("Fold should return init for no items %+v", res)
+	}
+}
+
+func atoi(s string) Result[int] {
+	return NewResult(strconv.Atoi(s))
+}
+
+func TestMapSlice(t *testing.T) {
+	res := MapSlice([]string{"1", "2", "3"}, atoi)
+	if res.IsErr() || len(res.Ok) != 3 || res.Ok[0] != 1 || res.Ok[2] != 3 {
+		t.Fatalf("MapSlice should return all values in order %+v", res)
+	}
+}
+
+func TestMapSliceError(t *testing.T) {
+	res := MapSlice([]string{"1", "x", "y"}, atoi)
+	if res.IsOk() || res.Err.Error() != `index 1: strconv.Atoi: parsing "x": invalid syntax` {
+		t.Fatalf("MapSlice should return the first error with its index %+v", res)
+	}
+	if !errors.Is(res.Err, strconv.ErrSyntax) {
+		t.Fatalf("original error should be unwrappable %+v", res)
+	}
+}
+
+func TestTryMapSlice(t *testing.T) {
+	vals, err := TryMapSlice([]string{"1", "x", "3", "y"}, atoi)
+	if len(vals) != 2 || vals[0] != 1 || vals[1] != 3 {
+		t.Fatalf("TryMapSlice should return the Ok values in order %v", vals)
+	}
+	want := "index 1: strconv.Atoi: parsing \"x\": invalid syntax\n" +
+		"index 3: strconv.Atoi: parsing \"y\": invalid syntax"
+	if err == nil || err.Error() != want || !errors.Is(err, strconv.ErrSyntax) {
+		t.Fatalf("TryMapSlice should join all errors, got %v", err)
+	}
+	if _, err := TryMapSlice([]string{"1"}, atoi); err != nil {
+		t.Fatalf("error should be nil, got %v", err)
+	}
+}
+
+func TestNewResults(t *testing.T) {
+	calls := 0
+	divideBy := func(y int) func() (int, error) {
+		return func() (int, error) {
+			calls++
+			return divide(12, y)
+		}
+	}
+	results := NewResults(divideBy(2), divideBy(0), divideBy(3))
+	if len(results) != 3 || calls != 3 {
+		t.Fatalf("every function should be called once %+v", results)
+	}
+	if results[0].Ok != 6 || results[1].IsOk() || results[2].Ok != 4 {
+		t.Fatalf("results should be in order %+v", results)
+	}
+}
+
+func TestSum(t *testing.T) {
+	if res := Sum([]Result[int]{doDivide(4, 2), doDivide(9, 3)}); res.IsErr() || res.Ok != 5 {
+		t.Fatalf("Sum should return 5 %+v", res)
+	}
+	if res := Sum([]Result[float64]{{Ok: 0.5}, {Ok: 1.25}}); res.IsErr() || res.Ok != 1.75 {
+		t.Fatalf("Sum should return 1.75 %+v", res)
+	}
+	if res := Sum([]Result[int]{doDivide(4, 2), doDivide(1, 0)}); res.IsOk() || res.Ok != 0 {
+		t.Fatalf("Sum should return the error %+v", res)
+	}
+	if res := Sum[int](nil); res.IsErr() || res.Ok != 0 {
+		t.Fatalf("Sum of no results should be 0 %+v", res)
+	}
+}
+
+func TestEhAll(t *testing.T) {
+	firstErr := errors.New("first")
+	sumAll := func(rs []Result[int]) (res Result[int]) {
+		defer EscapeHatch(&res)
+		for _, v := range EhAll(rs) {
+			res.Ok += v
+		}
+		return res
+	}
+	if res := sumAll([]Result[int]{{Ok: 1}, {Ok: 2}}); res.IsErr() || res.Ok != 3 {
+		t.Fatalf("all values should be returned %+v", res)
+	}
+	if res := sumAll([]Result[int]{{Ok: 1}, {Err: firstErr}, {Err: errors.New("second")}}); res.Err != firstErr {
+		t.Fatalf("first error should escape %+v", res)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"database/sql"
+	"database/sql/driver"
+)
+
+// Scan implements the sql.Scanner interface so that a nullable column can be
+// scanned directly into a Result. A NULL value results in a Result whose
+// error is ErrNull, any other value is converted to T and stored as the Ok
+// value. The conversion follows the same rules as scanning into a *T with
+// database/sql, so T can be any type supported by database/sql, including
+// types that implement sql.Scanner themselves.
+//
+// Example:
+//
+//	var email eh.Result[string]
+//	err := db.QueryRow("SELECT email FROM users WHERE id = ?", id).Scan(&email)
+func (r *Result[T]) Scan(src any) error {
+	var v sql.Null[T]
+	if err := v.Scan(src); err != nil {
+		return err
+	}
+	if !v.Valid {
+		*r = Result[T]{Err: ErrNull}
+		return nil
+	}
+	*r = Result[T]{Ok: v.V}
+	return nil
+}
+
+// Value implements the driver.Valuer interface. It returns NULL if there is
+// an error and otherwise the Ok value converted to a driver.Value with
+// driver.DefaultParameterConverter, so that T can be any type accepted as a
+// query argument by database/sql, including types that implement
+// driver.Valuer themselves.
+func (r Result[T]) Value() (driver.Value, error) {
+	if r.Err != nil {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(r.Ok)
+}
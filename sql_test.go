@@ -0,0 +1,75 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+)
+
+var (
+	_ sql.Scanner   = &Result[string]{}
+	_ driver.Valuer = Result[string]{}
+)
+
+func TestScan(t *testing.T) {
+	var res Result[string]
+	if err := res.Scan([]byte("value")); err != nil || res.IsErr() || res.Ok != "value" {
+		t.Fatalf("value should be scanned %+v %v", res, err)
+	}
+	var num Result[int]
+	if err := num.Scan(int64(2)); err != nil || num.IsErr() || num.Ok != 2 {
+		t.Fatalf("value should be converted %+v %v", num, err)
+	}
+}
+
+func TestScanNull(t *testing.T) {
+	res := Result[string]{Ok: "value"}
+	if err := res.Scan(nil); err != nil || !errors.Is(res.Err, ErrNull) || res.Ok != "" {
+		t.Fatalf("NULL should result in ErrNull %+v %v", res, err)
+	}
+}
+
+func TestScanInvalid(t *testing.T) {
+	var res Result[int]
+	if err := res.Scan("not a number"); err == nil {
+		t.Fatalf("scanning should fail %+v", res)
+	}
+}
+
+func TestValue(t *testing.T) {
+	if val, err := (Result[int]{Ok: 2}).Value(); err != nil || val != int64(2) {
+		t.Fatalf("Ok value should be returned, got %v %v", val, err)
+	}
+	if val, err := (Result[int]{Err: ErrNull}).Value(); err != nil || val != nil {
+		t.Fatalf("NULL should be returned, got %v %v", val, err)
+	}
+}
+
+type celsius float32
+
+func TestValueConversion(t *testing.T) {
+	if val, err := (Result[celsius]{Ok: 1.5}).Value(); err != nil || val != float64(1.5) {
+		t.Fatalf("value should be converted to a driver.Value, got %T(%v) %v", val, val, err)
+	}
+	if val, err := (Result[sql.NullString]{Ok: sql.NullString{String: "a", Valid: true}}).Value(); err != nil || val != "a" {
+		t.Fatalf("driver.Valuer should be used, got %v %v", val, err)
+	}
+	if _, err := (Result[struct{}]{}).Value(); err == nil {
+		t.Fatal("unsupported type should return an error")
+	}
+}
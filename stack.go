@@ -0,0 +1,108 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+var (
+	captureStack atomic.Bool
+	autoWrap     atomic.Bool
+)
+
+// SetCaptureStack enables or disables capturing a stack trace at the point
+// where Eh() escapes with an error. When enabled, the error that EscapeHatch
+// stores in the Result has a `StackTrace() []uintptr` method that returns the
+// captured program counters, which can be formatted with runtime.CallersFrames.
+// The original error is still available through errors.Unwrap, errors.Is and
+// errors.As. Capturing is off by default because it adds overhead to every
+// escaped error.
+func SetCaptureStack(enabled bool) {
+	captureStack.Store(enabled)
+}
+
+// SetAutoWrap enables or disables wrapping errors that escape through Eh()
+// with the name of the function that called Eh() and the file and line of
+// the call. For example an error escaping from a function named example
+// reads like
+// `example (main.go:12): open non-existing-file: no such file or directory`.
+// The original
+// error is still available through errors.Unwrap. An error is only wrapped
+// the first time it escapes, so nested escape hatch functions do not add
+// more locations to it. Wrapping is off by default.
+func SetAutoWrap(enabled bool) {
+	autoWrap.Store(enabled)
+}
+
+// callerError annotates an escaped error with the name and location of the
+// function that called Eh().
+type callerError struct {
+	caller string
+	err    error
+}
+
+func (e *callerError) Error() string {
+	return e.caller + ": " + e.err.Error()
+}
+
+func (e *callerError) Unwrap() error {
+	return e.err
+}
+
+// hasCaller reports whether err was already wrapped with the location of a
+// caller.
+func hasCaller(err error) bool {
+	var ce *callerError
+	return errors.As(err, &ce)
+}
+
+// callerLocation returns the name of a function on the calling goroutine's
+// stack without its package path, followed by the file name and line in
+// parentheses, skipping the given number of frames (0 identifies the caller
+// of callerLocation).
+func callerLocation(skip int) string {
+	pc, file, line, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return "unknown"
+	}
+	name := "unknown"
+	if fn := runtime.FuncForPC(pc); fn != nil {
+		name = fn.Name()
+		name = name[strings.LastIndex(name, "/")+1:]
+		name = name[strings.Index(name, ".")+1:]
+	}
+	return fmt.Sprintf("%s (%s:%d)", name, filepath.Base(file), line)
+}
+
+// hasStackTrace reports whether a stack trace was already captured for err,
+// this keeps the stack of the origin when an error escapes multiple times.
+func hasStackTrace(err error) bool {
+	var st interface{ StackTrace() []uintptr }
+	return errors.As(err, &st) && st.StackTrace() != nil
+}
+
+// callers returns the program counters of the calling goroutine's stack,
+// skipping the given number of frames (0 identifies the caller of callers).
+func callers(skip int) []uintptr {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(skip+2, pcs)
+	return pcs[:n]
+}
@@ -0,0 +1,99 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"errors"
+	"regexp"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func stackFunctions(err error) []string {
+	var st interface{ StackTrace() []uintptr }
+	if !errors.As(err, &st) {
+		return nil
+	}
+	var names []string
+	frames := runtime.CallersFrames(st.StackTrace())
+	for {
+		frame, more := frames.Next()
+		names = append(names, frame.Function)
+		if !more {
+			break
+		}
+	}
+	return names
+}
+
+func TestCaptureStack(t *testing.T) {
+	SetCaptureStack(true)
+	defer SetCaptureStack(false)
+	res := doDivideMultiple(4, 0)
+	names := stackFunctions(res.Err)
+	if len(names) == 0 || !strings.HasSuffix(names[0], ".doDivideMultiple") {
+		t.Fatalf("stack should start at doDivideMultiple, got %v", names)
+	}
+	if res.Err.Error() != "divide by zero" || errors.Unwrap(res.Err) == nil {
+		t.Fatalf("original error should be preserved %+v", res)
+	}
+}
+
+func TestCaptureStackKeepsOrigin(t *testing.T) {
+	SetCaptureStack(true)
+	defer SetCaptureStack(false)
+	outer := func() (res Result[int]) {
+		defer EscapeHatch(&res)
+		return Result[int]{Ok: doDivideMultiple(4, 0).Eh()}
+	}
+	names := stackFunctions(outer().Err)
+	if len(names) == 0 || !strings.HasSuffix(names[0], ".doDivideMultiple") {
+		t.Fatalf("stack should start at doDivideMultiple, got %v", names)
+	}
+}
+
+func TestCaptureStackDisabled(t *testing.T) {
+	res := doDivideMultiple(4, 0)
+	if stackFunctions(res.Err) != nil {
+		t.Fatalf("stack should not be captured by default %+v", res)
+	}
+}
+
+var wrappedByDivideMultiple = regexp.MustCompile(`^doDivideMultiple \(eh_test\.go:\d+\): divide by zero$`)
+
+func TestAutoWrap(t *testing.T) {
+	SetAutoWrap(true)
+	defer SetAutoWrap(false)
+	res := doDivideMultiple(4, 0)
+	if !wrappedByDivideMultiple.MatchString(res.Err.Error()) {
+		t.Fatalf("error should be wrapped with the caller location %+v", res)
+	}
+	if errors.Unwrap(res.Err).Error() != "divide by zero" {
+		t.Fatalf("original error should be unwrappable %+v", res)
+	}
+}
+
+func TestAutoWrapOnce(t *testing.T) {
+	SetAutoWrap(true)
+	defer SetAutoWrap(false)
+	outer := func() (res Result[int]) {
+		defer EscapeHatch(&res)
+		return Result[int]{Ok: doDivideMultiple(4, 0).Eh()}
+	}
+	if res := outer(); !wrappedByDivideMultiple.MatchString(res.Err.Error()) {
+		t.Fatalf("error should only be wrapped once %+v", res)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sync/atomic"
+)
+
+var strictMode atomic.Bool
+
+// stderr and exit are used by ProtectMain, tests replace them.
+var (
+	stderr io.Writer = os.Stderr
+	exit             = os.Exit
+)
+
+// SetStrictMode enables or disables strict mode, which is meant to be used
+// during development and testing. A common mistake is to call Eh() in a
+// function that does not defer an escape hatch, so that the panic is not
+// recovered and crashes the program. In strict mode the message of such a
+// panic says that an escape hatch is probably missing. Strict mode does not
+// change how errors are recovered and is off by default.
+func SetStrictMode(enabled bool) {
+	strictMode.Store(enabled)
+}
+
+// ProtectMain calls f and reports an error that escaped through Eh() without
+// being recovered by an escape hatch. The error is printed to stderr, along
+// with its stack trace if one was captured, and the program exits with
+// status 2. Other panics are not recovered.
+//
+// Example:
+//
+//	func main() {
+//		eh.SetStrictMode(true)
+//		eh.ProtectMain(run)
+//	}
+func ProtectMain(f func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(ehError)
+			if !ok {
+				panic(r)
+			}
+			reportLeak(e)
+			exit(2)
+		}
+	}()
+	f()
+}
+
+// reportLeak prints an error that was not recovered by an escape hatch.
+func reportLeak(e ehError) {
+	fmt.Fprintf(stderr, "eh: error escaped without an escape hatch: %v\n", e.error)
+	var st interface{ StackTrace() []uintptr }
+	if !errors.As(e.cause(), &st) || st.StackTrace() == nil {
+		return
+	}
+	frames := runtime.CallersFrames(st.StackTrace())
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(stderr, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			return
+		}
+	}
+}
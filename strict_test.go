@@ -0,0 +1,67 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestStrictMode(t *testing.T) {
+	SetStrictMode(true)
+	defer SetStrictMode(false)
+	msg := leakedMessage(func() { NewResult(divide(1, 0)).Eh() })
+	if !strings.Contains(msg, "escape hatch") || !strings.HasSuffix(msg, "divide by zero") {
+		t.Fatalf("message should point out the missing escape hatch %q", msg)
+	}
+	if res := doDivideMultiple(4, 0); res.Err.Error() != "divide by zero" {
+		t.Fatalf("recovered error should be unchanged %+v", res)
+	}
+}
+
+// leakedMessage returns the message that a panic raised by f is printed with
+// when it is not recovered.
+func leakedMessage(f func()) (msg string) {
+	defer func() {
+		msg = recover().(error).Error()
+	}()
+	f()
+	return ""
+}
+
+func TestProtectMain(t *testing.T) {
+	var buf bytes.Buffer
+	code := -1
+	origStderr, origExit := stderr, exit
+	stderr, exit = &buf, func(c int) { code = c }
+	defer func() {
+		stderr, exit = origStderr, origExit
+	}()
+	SetCaptureStack(true)
+	defer SetCaptureStack(false)
+	ProtectMain(func() { NewResult(divide(1, 0)).Eh() })
+	if code != 2 || !strings.HasPrefix(buf.String(), "eh: error escaped without an escape hatch: divide by zero\n") {
+		t.Fatalf("leaked error should be reported, got %d %q", code, buf.String())
+	}
+	if !strings.Contains(buf.String(), "TestProtectMain") {
+		t.Fatalf("stack trace should be reported, got %q", buf.String())
+	}
+	code = -1
+	ProtectMain(func() {})
+	if code != -1 {
+		t.Fatalf("exit should not be called, got %d", code)
+	}
+}
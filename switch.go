@@ -0,0 +1,94 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"errors"
+)
+
+// ErrorSwitch handles the error of a Result with the handler of the first
+// matching case, like a switch statement over errors. It is created with
+// Switch and applied by deferring its Handle method.
+type ErrorSwitch[T any] struct {
+	res   *Result[T]
+	cases []errorCase[T]
+	def   func(error) T
+}
+
+type errorCase[T any] struct {
+	target  error
+	handler func(error) T
+}
+
+// Switch returns an ErrorSwitch for the Result pointed by res. It replaces a
+// stack of deferred CatchError calls with a single deferred call. The cases
+// are checked in the order they were added and the first one whose target
+// matches the error, as reported by errors.Is, replaces the error with the
+// value returned by its handler. If no case matches the default handler is
+// used, and if there is no default handler the error is left in the Result.
+// Like in CatchError, a handler can call Eh() to escape with a new error to
+// the escape hatches deferred before the switch.
+//
+// Example:
+//
+//	func example() (r eh.Result[string]) {
+//		defer eh.EscapeHatch(&r)
+//		defer eh.Switch(&r).
+//			Case(ErrNotInMemory, func(_ error) string {
+//				return eh.NewResult(GetFromDb()).Eh()
+//			}).
+//			Case(ErrNotInDB, func(_ error) string {
+//				return eh.NewResult(GetFromRemote()).Eh()
+//			}).
+//			Handle()
+//		return eh.Result[string]{Ok: eh.NewResult(GetFromInMemory()).Eh()}
+//	}
+func Switch[T any](res *Result[T]) *ErrorSwitch[T] {
+	return &ErrorSwitch[T]{res: res}
+}
+
+// Case adds a case that handles the errors matching target with handler.
+func (s *ErrorSwitch[T]) Case(target error, handler func(error) T) *ErrorSwitch[T] {
+	s.cases = append(s.cases, errorCase[T]{target, handler})
+	return s
+}
+
+// Default sets the handler used when no case matches the error.
+func (s *ErrorSwitch[T]) Default(handler func(error) T) *ErrorSwitch[T] {
+	s.def = handler
+	return s
+}
+
+// Handle recovers an error escaping through Eh(), like EscapeHatch, and
+// handles the error of the Result with the matching case. It has to be
+// called with defer.
+func (s *ErrorSwitch[T]) Handle() {
+	if r := recover(); r != nil {
+		*s.res = Result[T]{Err: recovered(r)}
+	}
+	if s.res.IsOk() {
+		return
+	}
+	err := s.res.Err
+	for _, c := range s.cases {
+		if errors.Is(err, c.target) {
+			*s.res = Result[T]{Ok: c.handler(err)}
+			return
+		}
+	}
+	if s.def != nil {
+		*s.res = Result[T]{Ok: s.def(err)}
+	}
+}
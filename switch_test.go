@@ -0,0 +1,53 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSwitch(t *testing.T) {
+	errFirst := errors.New("first")
+	errSecond := errors.New("second")
+	errOther := errors.New("other")
+	withSwitch := func(err error, def bool) (r Result[int]) {
+		defer EscapeHatch(&r)
+		s := Switch(&r).
+			Case(errFirst, func(_ error) int { return 1 }).
+			Case(errSecond, func(_ error) int { return FromFailableT[int](errOther).Eh() }).
+			Case(errFirst, func(_ error) int { return 3 })
+		if def {
+			s.Default(func(_ error) int { return 100 })
+		}
+		defer s.Handle()
+		return Result[int]{Ok: FromFailableT[int](err).Eh()}
+	}
+	if res := withSwitch(nil, false); res.IsErr() || res.Ok != 0 {
+		t.Fatalf("Ok result should be unchanged %+v", res)
+	}
+	if res := withSwitch(errFirst, false); res.IsErr() || res.Ok != 1 {
+		t.Fatalf("first matching case should win %+v", res)
+	}
+	if res := withSwitch(errSecond, false); res.Err != errOther {
+		t.Fatalf("error escaping from a handler should propagate %+v", res)
+	}
+	if res := withSwitch(errOther, false); res.Err != errOther {
+		t.Fatalf("unmatched error should be kept %+v", res)
+	}
+	if res := withSwitch(errOther, true); res.IsErr() || res.Ok != 100 {
+		t.Fatalf("default handler should be used %+v", res)
+	}
+}
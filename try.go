@@ -0,0 +1,90 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"fmt"
+)
+
+// Try runs f and converts any panic it raises into an errored Result. This
+// is useful for calling code that panics instead of returning errors. Errors
+// escaping through Eh() are unwrapped to the original error, other panic
+// values that are not errors are formatted with `%v`.
+//
+// Example:
+//
+//	re := eh.Try(func() *regexp.Regexp { return regexp.MustCompile(pattern) }).Eh()
+func Try[T any](f func() T) (res Result[T]) {
+	defer func() {
+		if r := recover(); r != nil {
+			res = Result[T]{Err: panicError(r)}
+		}
+	}()
+	return Result[T]{Ok: f()}
+}
+
+// TryErr runs f and returns any panic it raises as an error, or nil if f
+// returns normally. Errors escaping through Eh() are unwrapped to the original
+// error, other panic values that are not errors are formatted with `%v`.
+func TryErr(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicError(r)
+		}
+	}()
+	f()
+	return nil
+}
+
+// Guard wraps f so that any panic raised by it is converted into an errored
+// Result, which is useful to harden callbacks passed to functions such as
+// ParallelMap or Retry. Errors escaping through Eh() are unwrapped to the
+// original error, other panic values are formatted as "panic: %v" and errors
+// among them can still be matched with errors.Is and errors.As. The Result
+// returned by f is returned unchanged when it does not panic.
+func Guard[T any](f func() Result[T]) func() Result[T] {
+	return func() (res Result[T]) {
+		defer func() {
+			if r := recover(); r != nil {
+				res = Result[T]{Err: guardError(r)}
+			}
+		}()
+		return f()
+	}
+}
+
+// guardError converts a panic value recovered by Guard into an error.
+func guardError(r any) error {
+	switch v := r.(type) {
+	case ehError:
+		return v.cause()
+	case error:
+		return fmt.Errorf("panic: %w", v)
+	default:
+		return fmt.Errorf("panic: %v", r)
+	}
+}
+
+// panicError converts a recovered panic value into an error.
+func panicError(r any) error {
+	switch v := r.(type) {
+	case ehError:
+		return v.cause()
+	case error:
+		return v
+	default:
+		return fmt.Errorf("%v", r)
+	}
+}
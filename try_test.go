@@ -0,0 +1,80 @@
+// Copyright © 2023 Tasko Olevski
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eh
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTry(t *testing.T) {
+	res := Try(func() int { return 1 })
+	if res.IsErr() || res.Ok != 1 {
+		t.Fatalf("Try should return the value %+v", res)
+	}
+}
+
+func TestTryPanic(t *testing.T) {
+	res := Try(func() int { panic("boom") })
+	if res.IsOk() || res.Err.Error() != "boom" {
+		t.Fatalf("Try should convert the panic into an error %+v", res)
+	}
+	aErr := errors.New("error")
+	res = Try(func() int { panic(aErr) })
+	if res.Err != aErr {
+		t.Fatalf("Try should keep an error panic value %+v", res)
+	}
+}
+
+func TestTryEh(t *testing.T) {
+	res := Try(func() int { return NewResult(divide(1, 0)).Eh() })
+	if res.IsOk() || res.Err.Error() != "divide by zero" {
+		t.Fatalf("Try should unwrap the escaped error %+v", res)
+	}
+	if _, ok := res.Err.(ehError); ok {
+		t.Fatal("Try should not return an ehError")
+	}
+}
+
+func TestTryErr(t *testing.T) {
+	if err := TryErr(func() {}); err != nil {
+		t.Fatalf("TryErr should return nil, got %v", err)
+	}
+	if err := TryErr(func() { panic(42) }); err == nil || err.Error() != "42" {
+		t.Fatalf("TryErr should convert the panic into an error, got %v", err)
+	}
+	err := TryErr(func() { FromFailable(errors.New("error")).Eh() })
+	if _, ok := err.(ehError); ok || err.Error() != "error" {
+		t.Fatalf("TryErr should unwrap the escaped error, got %v", err)
+	}
+}
+
+func TestGuard(t *testing.T) {
+	if res := Guard(func() Result[int] { return doDivide(4, 2) })(); res.Ok != 2 || res.IsErr() {
+		t.Fatalf("Guard should return the original result %+v", res)
+	}
+	res := Guard(func() Result[int] { panic(42) })()
+	if res.IsOk() || res.Err.Error() != "panic: 42" {
+		t.Fatalf("Guard should convert the panic into an error %+v", res)
+	}
+	aErr := errors.New("error")
+	if res := Guard(func() Result[int] { panic(aErr) })(); !errors.Is(res.Err, aErr) {
+		t.Fatalf("Guard should wrap an error panic value %+v", res)
+	}
+	res = Guard(func() Result[int] { return Result[int]{Ok: NewResult(divide(1, 0)).Eh()} })()
+	if _, ok := res.Err.(ehError); ok || res.Err.Error() != "divide by zero" {
+		t.Fatalf("Guard should unwrap the escaped error %+v", res)
+	}
+}